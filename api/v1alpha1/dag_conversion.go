@@ -0,0 +1,263 @@
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1beta1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// ConvertTo converts this v1alpha1 Dag to the v1beta1 hub version.
+func (src *Dag) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Dag)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Tasks = convertTaskSpecsTo(src.Spec.Tasks)
+	dst.Spec.Finally = convertTaskSpecsTo(src.Spec.Finally)
+	dst.Spec.Schedule = convertScheduleTo(src.Spec.Schedule)
+	dst.Spec.RetryBudget = src.Spec.RetryBudget
+	dst.Status = convertDagStatusTo(src.Status)
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version into this v1alpha1 Dag,
+// dropping fields v1alpha1 has no equivalent for (currently
+// TaskSpec.Workspaces).
+func (dst *Dag) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Dag)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec.Tasks = convertTaskSpecsFrom(src.Spec.Tasks)
+	dst.Spec.Finally = convertTaskSpecsFrom(src.Spec.Finally)
+	dst.Spec.Schedule = convertScheduleFrom(src.Spec.Schedule)
+	dst.Spec.RetryBudget = src.Spec.RetryBudget
+	dst.Status = convertDagStatusFrom(src.Status)
+	return nil
+}
+
+func convertTaskSpecsTo(tasks []TaskSpec) []v1beta1.TaskSpec {
+	if tasks == nil {
+		return nil
+	}
+	out := make([]v1beta1.TaskSpec, len(tasks))
+	for i, t := range tasks {
+		out[i] = v1beta1.TaskSpec{
+			Name:         t.Name,
+			Type:         v1beta1.TaskType(t.Type),
+			Dependencies: t.Dependencies,
+			Command:      t.Command,
+			Script:       t.Script,
+			Image:        t.Image,
+			ScriptRef:    (*v1beta1.ScriptRef)(t.ScriptRef),
+			Ref:          (*v1beta1.TaskRef)(t.Ref),
+			CustomRef:    (*v1beta1.CustomTaskRef)(t.CustomRef),
+			RetryPolicy:  (*v1beta1.RetryPolicy)(t.RetryPolicy),
+			Timeout:      t.Timeout,
+			Env:          t.Env,
+			BranchRules:  convertBranchRulesTo(t.BranchRules),
+			Retries:      t.Retries,
+			RetryBackoff: t.RetryBackoff,
+			Priority:     v1beta1.TaskPriority(t.Priority),
+			NodeSelector: t.NodeSelector,
+			// Workspaces has no v1alpha1 equivalent; left empty.
+		}
+	}
+	return out
+}
+
+func convertTaskSpecsFrom(tasks []v1beta1.TaskSpec) []TaskSpec {
+	if tasks == nil {
+		return nil
+	}
+	out := make([]TaskSpec, len(tasks))
+	for i, t := range tasks {
+		out[i] = TaskSpec{
+			Name:         t.Name,
+			Type:         TaskType(t.Type),
+			Dependencies: t.Dependencies,
+			Command:      t.Command,
+			Script:       t.Script,
+			Image:        t.Image,
+			ScriptRef:    (*ScriptRef)(t.ScriptRef),
+			Ref:          (*TaskRef)(t.Ref),
+			CustomRef:    (*CustomTaskRef)(t.CustomRef),
+			RetryPolicy:  (*RetryPolicy)(t.RetryPolicy),
+			Timeout:      t.Timeout,
+			Env:          t.Env,
+			BranchRules:  convertBranchRulesFrom(t.BranchRules),
+			Retries:      t.Retries,
+			RetryBackoff: t.RetryBackoff,
+			Priority:     TaskPriority(t.Priority),
+			NodeSelector: t.NodeSelector,
+			// t.Workspaces is dropped: v1alpha1 has no field for it.
+		}
+	}
+	return out
+}
+
+func convertBranchRulesTo(rules []BranchRule) []v1beta1.BranchRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]v1beta1.BranchRule, len(rules))
+	for i, r := range rules {
+		out[i] = v1beta1.BranchRule(r)
+	}
+	return out
+}
+
+func convertBranchRulesFrom(rules []v1beta1.BranchRule) []BranchRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]BranchRule, len(rules))
+	for i, r := range rules {
+		out[i] = BranchRule(r)
+	}
+	return out
+}
+
+func convertScheduleTo(s *ScheduleSpec) *v1beta1.ScheduleSpec {
+	if s == nil {
+		return nil
+	}
+	return &v1beta1.ScheduleSpec{
+		CronExpression:          s.CronExpression,
+		Timezone:                s.Timezone,
+		ConcurrencyPolicy:       v1beta1.ConcurrencyPolicy(s.ConcurrencyPolicy),
+		StartingDeadlineSeconds: s.StartingDeadlineSeconds,
+		Suspend:                 s.Suspend,
+		TriggerPolicy:           convertTriggerPolicyTo(s.TriggerPolicy),
+	}
+}
+
+func convertScheduleFrom(s *v1beta1.ScheduleSpec) *ScheduleSpec {
+	if s == nil {
+		return nil
+	}
+	return &ScheduleSpec{
+		CronExpression:          s.CronExpression,
+		Timezone:                s.Timezone,
+		ConcurrencyPolicy:       ConcurrencyPolicy(s.ConcurrencyPolicy),
+		StartingDeadlineSeconds: s.StartingDeadlineSeconds,
+		Suspend:                 s.Suspend,
+		TriggerPolicy:           convertTriggerPolicyFrom(s.TriggerPolicy),
+	}
+}
+
+func convertTriggerPolicyTo(p *TriggerPolicy) *v1beta1.TriggerPolicy {
+	if p == nil {
+		return nil
+	}
+	return &v1beta1.TriggerPolicy{
+		Duration:        p.Duration,
+		Weekday:         p.Weekday,
+		OffsetSeconds:   p.OffsetSeconds,
+		MissedRunPolicy: v1beta1.MissedRunPolicy(p.MissedRunPolicy),
+	}
+}
+
+func convertTriggerPolicyFrom(p *v1beta1.TriggerPolicy) *TriggerPolicy {
+	if p == nil {
+		return nil
+	}
+	return &TriggerPolicy{
+		Duration:        p.Duration,
+		Weekday:         p.Weekday,
+		OffsetSeconds:   p.OffsetSeconds,
+		MissedRunPolicy: MissedRunPolicy(p.MissedRunPolicy),
+	}
+}
+
+func convertDagStatusTo(s DagStatus) v1beta1.DagStatus {
+	return v1beta1.DagStatus{
+		State:        v1beta1.TaskState(s.State),
+		TaskStatuses: convertTaskStatusesTo(s.TaskStatuses),
+		RetriesUsed:  s.RetriesUsed,
+	}
+}
+
+func convertDagStatusFrom(s v1beta1.DagStatus) DagStatus {
+	return DagStatus{
+		State:        TaskState(s.State),
+		TaskStatuses: convertTaskStatusesFrom(s.TaskStatuses),
+		RetriesUsed:  s.RetriesUsed,
+	}
+}
+
+func convertTaskStatusesTo(statuses []TaskStatus) []v1beta1.TaskStatus {
+	if statuses == nil {
+		return nil
+	}
+	out := make([]v1beta1.TaskStatus, len(statuses))
+	for i, s := range statuses {
+		out[i] = v1beta1.TaskStatus{
+			Name:           s.Name,
+			State:          v1beta1.TaskState(s.State),
+			PodName:        s.PodName,
+			Message:        s.Message,
+			Attempts:       s.Attempts,
+			LastError:      s.LastError,
+			Outputs:        s.Outputs,
+			Results:        s.Results,
+			AttemptHistory: convertAttemptHistoryTo(s.AttemptHistory),
+			NodeID:         s.NodeID,
+		}
+	}
+	return out
+}
+
+func convertTaskStatusesFrom(statuses []v1beta1.TaskStatus) []TaskStatus {
+	if statuses == nil {
+		return nil
+	}
+	out := make([]TaskStatus, len(statuses))
+	for i, s := range statuses {
+		out[i] = TaskStatus{
+			Name:           s.Name,
+			State:          TaskState(s.State),
+			PodName:        s.PodName,
+			Message:        s.Message,
+			Attempts:       s.Attempts,
+			LastError:      s.LastError,
+			Outputs:        s.Outputs,
+			Results:        s.Results,
+			AttemptHistory: convertAttemptHistoryFrom(s.AttemptHistory),
+			NodeID:         s.NodeID,
+		}
+	}
+	return out
+}
+
+func convertAttemptHistoryTo(attempts []AttemptStatus) []v1beta1.AttemptStatus {
+	if attempts == nil {
+		return nil
+	}
+	out := make([]v1beta1.AttemptStatus, len(attempts))
+	for i, a := range attempts {
+		out[i] = v1beta1.AttemptStatus{
+			PodName:    a.PodName,
+			StartTime:  a.StartTime,
+			FinishTime: a.FinishTime,
+			ExitCode:   a.ExitCode,
+			State:      v1beta1.TaskState(a.State),
+		}
+	}
+	return out
+}
+
+func convertAttemptHistoryFrom(attempts []v1beta1.AttemptStatus) []AttemptStatus {
+	if attempts == nil {
+		return nil
+	}
+	out := make([]AttemptStatus, len(attempts))
+	for i, a := range attempts {
+		out[i] = AttemptStatus{
+			PodName:    a.PodName,
+			StartTime:  a.StartTime,
+			FinishTime: a.FinishTime,
+			ExitCode:   a.ExitCode,
+			State:      TaskState(a.State),
+		}
+	}
+	return out
+}
@@ -0,0 +1,390 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskType은 지원할 Operator 종류를 정의합니다.
+type TaskType string
+
+const (
+	TaskTypeBash   TaskType = "Bash"
+	TaskTypePython TaskType = "Python"
+	TaskTypeGo     TaskType = "Go"
+	// TaskTypeCustom delegates execution entirely to the ExternalResourceConnector
+	// registered for TaskSpec.CustomRef.APIVersion/Kind, Tekton "Run" style.
+	TaskTypeCustom TaskType = "Custom"
+	// TaskTypeRun delegates execution to whatever external controller
+	// reconciles the GVK named in TaskSpec.Ref, via a generic TaskRun
+	// handoff object (see TaskRunSpec) instead of a per-backend
+	// ExternalResourceConnector. Unlike TaskTypeCustom, no connector needs
+	// to be registered in Nautikus itself for this to work.
+	TaskTypeRun TaskType = "Run"
+	// TaskTypeSysBatch fans a single logical task out to every node an
+	// eligible executor reports via executor.Executor.Instances, matching
+	// TaskSpec.NodeSelector. The Runner/scheduler treats it as complete only
+	// once every per-node instance reaches a terminal state.
+	TaskTypeSysBatch TaskType = "SysBatch"
+)
+
+// TaskSpec은 DAG 내부의 개별 작업 단위입니다.
+type TaskSpec struct {
+	Name         string   `json:"name"`
+	Type         TaskType `json:"type"`
+	Dependencies []string `json:"dependencies,omitempty"` // 이 Task가 실행되기 위해 완료되어야 할 부모 Task들
+
+	// Operator별 실행 내용
+	Command string `json:"command,omitempty"` // Bash용
+	Script  string `json:"script,omitempty"`  // Python/Go 코드 본문
+	Image   string `json:"image,omitempty"`   // 커스텀 이미지 사용 시
+
+	// ScriptRef points at content-addressed storage holding this task's
+	// script instead of inlining it into Script. The SDK sets this for
+	// large sources so the Dag CR stays small enough to fit etcd's
+	// per-object size limit; the Pod executor fetches it via an init
+	// container before the main container runs.
+	ScriptRef *ScriptRef `json:"scriptRef,omitempty"`
+
+	// Ref points at an external CRD that should execute this task instead of
+	// the built-in Pod executor (e.g. a KubeflowPipeline or SparkApplication).
+	// When set, the Runner dispatches the task to the ExternalResourceConnector
+	// registered for Ref.APIVersion/Ref.Kind instead of the executor registry.
+	Ref *TaskRef `json:"ref,omitempty"`
+
+	// CustomRef carries the connector and inline spec for a TaskTypeCustom
+	// task. Unlike Ref (which points at an existing or connector-named
+	// object), CustomRef's Spec is opaque user payload the connector
+	// interprets when building the resource, mirroring Tekton's Run.
+	CustomRef *CustomTaskRef `json:"customRef,omitempty"`
+
+	// RetryPolicy controls how the Runner retries this task on executor
+	// failure. When nil, a failed task is marked Failed immediately.
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Timeout bounds how long a single attempt may run before it is
+	// considered failed, regardless of RetryPolicy.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is how many additional attempts DagReconciler.syncStatus gives
+	// a task whose Pod enters PodFailed before marking it StateFailed for
+	// good. Zero (the default) keeps the existing no-retry behavior. Unlike
+	// RetryPolicy (consumed by the executor-registry/Runner path), this
+	// governs the reconciler's own inline Pod management.
+	Retries int `json:"retries,omitempty"`
+
+	// RetryBackoff is how long syncStatus waits, via ctrl.Result.RequeueAfter,
+	// after deleting a failed attempt's Pod before creating the next one.
+	RetryBackoff metav1.Duration `json:"retryBackoff,omitempty"`
+
+	// Env carries environment variables the executor sets on the task's
+	// container. The SDK also uses it to tag branch/join metadata
+	// (e.g. NAUTIKUS_BRANCH_CONDITION) onto generated TaskSpecs.
+	Env map[string]string `json:"env,omitempty"`
+
+	// BranchRules makes this task a data-dependent branch condition: once
+	// it reaches StateCompleted, the Runner evaluates each rule in order
+	// against the run's task states/outputs and marks every sibling task
+	// whose Env["NAUTIKUS_BRANCH_CONDITION"] doesn't match the first rule
+	// that evaluates true as StateSkipped. Unlike the SDK's closure-based
+	// AddBranch (decided at manifest-generation time), this lets the
+	// decision depend on what upstream tasks actually produced.
+	BranchRules []BranchRule `json:"branchRules,omitempty"`
+
+	// Priority places this task into one of scheduler.DefaultScheduler's
+	// priority buckets when Policy is scheduler.PolicyPriority. Empty
+	// defaults to PriorityNormal.
+	Priority TaskPriority `json:"priority,omitempty"`
+
+	// NodeSelector restricts which nodes a TaskTypeSysBatch task fans out
+	// onto: the scheduler only emits a per-node clone for a node whose
+	// labels match every entry here. Ignored for every other TaskType.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// TaskPriority classifies a task into one of the scheduler's priority
+// buckets (see internal/scheduler.DefaultScheduler). The zero value behaves
+// as PriorityNormal.
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "Low"
+	PriorityNormal TaskPriority = "Normal"
+	PriorityHigh   TaskPriority = "High"
+	PriorityUrgent TaskPriority = "Urgent"
+)
+
+// BranchRule maps a small boolean expression to the branch that should run
+// when it evaluates true. Expression supports a CEL-like subset referencing
+// tasks.<name>.state and tasks.<name>.outputs.<key> (see internal/branch).
+type BranchRule struct {
+	// Expression is evaluated against the run's current task statuses,
+	// e.g. `tasks.check_size.outputs.count > "100"`.
+	Expression string `json:"expression"`
+	// TargetBranch is the Env["NAUTIKUS_BRANCH_CONDITION"] value selected
+	// when Expression evaluates true.
+	TargetBranch string `json:"targetBranch"`
+}
+
+// RetryPolicy configures exponential backoff retries for a TaskSpec.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts allowed, including the
+	// first one. A task fails permanently once this many attempts failed.
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff metav1.Duration `json:"initialBackoff"`
+	// MaxBackoff caps the computed delay between retries.
+	MaxBackoff metav1.Duration `json:"maxBackoff"`
+	// BackoffFactor multiplies the backoff after each failed attempt
+	// (delay = min(InitialBackoff * BackoffFactor^attempt, MaxBackoff)).
+	BackoffFactor float64 `json:"backoffFactor,omitempty"`
+	// RetryableErrors restricts retries to errors whose message contains one
+	// of these substrings. When empty, every failure is retryable.
+	RetryableErrors []string `json:"retryableErrors,omitempty"`
+}
+
+// ScriptRef identifies content previously uploaded to a scriptstore backend.
+type ScriptRef struct {
+	// Digest is the content-addressed hash of the script, verified by the
+	// fetching init container after retrieval (see scriptstore.Digest).
+	Digest string `json:"digest"`
+	// StoreRef is the backend-specific location the content was uploaded
+	// to (e.g. "file://sha256:..." or "registry://host/repo:sha256-...").
+	StoreRef string `json:"storeRef"`
+}
+
+// TaskRef identifies an external Kubernetes custom resource that implements
+// the actual execution of a Task, Tekton "custom task" style.
+type TaskRef struct {
+	// APIVersion of the referenced resource (e.g. "kubeflow.org/v1")
+	APIVersion string `json:"apiVersion"`
+	// Kind of the referenced resource (e.g. "PyTorchJob")
+	Kind string `json:"kind"`
+	// Name of an existing object to reference instead of creating a new one.
+	// When empty, the connector creates a new object named after the task.
+	Name string `json:"name,omitempty"`
+	// Params are passed through as-is to whatever executes this task. For
+	// TaskTypeRun, they land in TaskRunSpec.Params for the external
+	// controller reconciling the referenced GVK to read.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// CustomTaskRef identifies the connector that should execute a TaskTypeCustom
+// task and carries the user-defined payload it needs to do so.
+type CustomTaskRef struct {
+	// APIVersion of the custom resource the connector builds (e.g. "spark.stackable.tech/v1alpha1")
+	APIVersion string `json:"apiVersion"`
+	// Kind of the custom resource the connector builds (e.g. "SparkApplication")
+	Kind string `json:"kind"`
+	// Spec is opaque user payload passed to the connector's BuildResource,
+	// interpreted however that connector sees fit (e.g. merged into the
+	// built object's .spec).
+	Spec json.RawMessage `json:"spec,omitempty"`
+}
+
+// DagSpec은 사용자가 정의하는 DAG의 전체 명세입니다.
+type DagSpec struct {
+	Tasks []TaskSpec `json:"tasks"`
+
+	// Schedule configures a recurring trigger that creates a DagRun on a
+	// cron cadence. When nil, the Dag is only triggered manually (or by
+	// whatever external client creates DagRuns for it).
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// Finally lists tasks that always run, in parallel, once every task in
+	// Tasks has reached a terminal state (Completed or Failed) — regardless
+	// of whether the main graph succeeded. The DAG's own state only
+	// transitions to Completed/Failed after these finish, mirroring
+	// Tekton's pipeline.finally.
+	Finally []TaskSpec `json:"finally,omitempty"`
+
+	// RetryBudget caps the total number of task retries
+	// DagReconciler.syncStatus will spend across every task in the DAG
+	// (tracked in Status.RetriesUsed), so a pathological TaskSpec.Retries on
+	// many tasks can't retry forever. Zero means unlimited.
+	RetryBudget int `json:"retryBudget,omitempty"`
+}
+
+// ConcurrencyPolicy decides what happens when a scheduled trigger fires
+// while a previous run of the same Dag is still in progress.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow lets runs execute concurrently.
+	ConcurrencyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyForbid skips the new run if a previous one is still active.
+	ConcurrencyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyReplace stops the active run and starts the new one.
+	ConcurrencyReplace ConcurrencyPolicy = "Replace"
+)
+
+// ScheduleSpec configures a recurring trigger for a Dag, modelled on
+// Kubernetes CronJob. Exactly one of CronExpression or TriggerPolicy should
+// be set; TriggerPolicy takes precedence when both are.
+type ScheduleSpec struct {
+	// CronExpression is a standard 5-field cron expression (e.g. "0 * * * *").
+	CronExpression string `json:"cronExpression,omitempty"`
+	// Timezone the CronExpression is evaluated in (IANA name, e.g.
+	// "America/New_York"). Defaults to UTC when empty.
+	Timezone string `json:"timezone,omitempty"`
+	// ConcurrencyPolicy decides what happens when a fire is due while a
+	// previous run is still in progress. Defaults to ConcurrencyAllow.
+	// The schedule triggers do not read it: every due fire starts a run.
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// StartingDeadlineSeconds bounds how late a missed fire (e.g. after
+	// controller downtime) may still be started. A nil value means no deadline.
+	// The schedule triggers do not read it.
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// Suspend pauses the trigger without removing it, like CronJob.Suspend.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// TriggerPolicy configures a wall-clock interval trigger as an
+	// alternative to CronExpression, for cadences a 5-field cron expression
+	// can't express as directly (e.g. "every 90 minutes" or "weekly on
+	// Wednesday").
+	TriggerPolicy *TriggerPolicy `json:"triggerPolicy,omitempty"`
+}
+
+// TriggerPolicy configures a wall-clock interval cadence: each fire comes
+// one Duration after the previous one (the first one Duration after the
+// trigger registers or resumes the Dag), moved forward to OffsetSeconds past
+// 00:00 UTC when that is set and then to the next matching Weekday when that
+// is set. Fire times are not rounded to multiples of Duration.
+type TriggerPolicy struct {
+	// Duration is the interval between fires, e.g. "24h" for daily.
+	Duration metav1.Duration `json:"duration"`
+	// Weekday restricts fires to this ISO weekday (1=Monday..7=Sunday).
+	// Zero (the default) fires every Duration regardless of weekday.
+	Weekday int `json:"weekday,omitempty"`
+	// OffsetSeconds shifts each fire this many seconds past 00:00 UTC of
+	// its day, e.g. 3600 for 01:00 UTC instead of midnight.
+	OffsetSeconds int64 `json:"offsetSeconds,omitempty"`
+	// MissedRunPolicy controls catch-up behavior when the trigger polls late
+	// (e.g. after a process restart) and whole Durations have elapsed past a
+	// due fire. Fires that fall while the trigger is paused are dropped:
+	// resuming schedules the next fire one Duration later. Defaults to
+	// MissedRunRunOnce.
+	MissedRunPolicy MissedRunPolicy `json:"missedRunPolicy,omitempty"`
+}
+
+// MissedRunPolicy decides how an interval trigger catches up when it finds
+// a Dag's fire overdue by one or more whole Durations.
+type MissedRunPolicy string
+
+const (
+	// MissedRunSkip drops the overdue fire and every occurrence that
+	// elapsed after it; the next fire is whatever naturally comes next.
+	MissedRunSkip MissedRunPolicy = "Skip"
+	// MissedRunRunOnce fires exactly once to catch up, regardless of how
+	// many occurrences actually elapsed.
+	MissedRunRunOnce MissedRunPolicy = "RunOnce"
+	// MissedRunRunAll fires once for the overdue occurrence and once more
+	// for every occurrence that elapsed after it, with at most the
+	// trigger's catch-up cap of extra fires.
+	MissedRunRunAll MissedRunPolicy = "RunAll"
+)
+
+// TaskStatus는 개별 Task의 현재 상태입니다.
+type TaskState string
+
+const (
+	StatePending   TaskState = "Pending"
+	StateRunning   TaskState = "Running"
+	StateCompleted TaskState = "Completed"
+	StateFailed    TaskState = "Failed"
+	// StateSkipped marks a branch task the Runner decided not to run
+	// because a BranchRule on its condition task selected a different
+	// branch. Skipped tasks are treated like StateCompleted for dependency
+	// checks so downstream join tasks aren't blocked forever.
+	StateSkipped TaskState = "Skipped"
+)
+
+type TaskStatus struct {
+	Name    string    `json:"name"`
+	State   TaskState `json:"state"`
+	PodName string    `json:"podName,omitempty"`
+	Message string    `json:"message,omitempty"`
+
+	// Attempts counts how many times this task has been run, including the
+	// current one. Only meaningful when the TaskSpec has a RetryPolicy.
+	Attempts int `json:"attempts,omitempty"`
+	// LastError holds the error message from the most recent failed attempt.
+	LastError string `json:"lastError,omitempty"`
+
+	// Outputs holds the key/value pairs the Runner captured from this
+	// task's completion (its stdout JSON tail). BranchRule expressions
+	// reference these as tasks.<name>.outputs.<key>.
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// Results holds the key/value pairs the entrypoint binary captured from
+	// files the task wrote under its results directory. Downstream tasks
+	// and finally tasks reference these via $(tasks.<name>.result.<key>)
+	// (see internal/substitution), unlike Outputs which feeds BranchRule
+	// expressions instead.
+	Results map[string]string `json:"results,omitempty"`
+
+	// AttemptHistory records one entry per Pod attempt DagReconciler.syncStatus
+	// has created for this task, appended as each attempt's Pod enters
+	// PodFailed. Unlike Attempts (a simple count consumed by the
+	// executor-registry/Runner's RetryPolicy path), this keeps each attempt's
+	// own Pod name and outcome around so old attempts stay inspectable after
+	// a retry until their Pod is GC'd.
+	AttemptHistory []AttemptStatus `json:"attemptHistory,omitempty"`
+
+	// NodeID is set when this TaskStatus is one per-node instance of a
+	// TaskTypeSysBatch task's fanout (its Name is "<task>--<nodeID>"), empty
+	// for every other task's status.
+	NodeID string `json:"nodeID,omitempty"`
+}
+
+// AttemptStatus records one Pod attempt of a task made by
+// DagReconciler.syncStatus's TaskSpec.Retries handling.
+type AttemptStatus struct {
+	// PodName is the attempt's Pod, named "<dag>-<task>-<n>" so it doesn't
+	// collide with other attempts' Pods.
+	PodName string `json:"podName"`
+	// StartTime is the attempt Pod's observed start time.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// FinishTime is when the attempt's container terminated.
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+	// ExitCode is the attempt's container exit code.
+	ExitCode int32 `json:"exitCode,omitempty"`
+	// State is the attempt's outcome, StateFailed for every entry here today
+	// since AttemptHistory is only appended to on PodFailed.
+	State TaskState `json:"state"`
+}
+
+// DagStatus는 DAG 전체의 상태입니다.
+type DagStatus struct {
+	State        TaskState    `json:"state"` // DAG 전체 상태 (Running, Completed...)
+	TaskStatuses []TaskStatus `json:"taskStatuses,omitempty"`
+
+	// RetriesUsed counts retries spent so far against Spec.RetryBudget.
+	RetriesUsed int `json:"retriesUsed,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Dag is the Schema for the dags API
+type Dag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DagSpec   `json:"spec,omitempty"`
+	Status DagStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DagList contains a list of Dag
+type DagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Dag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Dag{}, &DagList{})
+}
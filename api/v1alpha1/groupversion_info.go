@@ -0,0 +1,26 @@
+// Package v1alpha1 contains API Schema definitions for the workflow
+// v1alpha1 API group. This is the original API version, kept so existing
+// workflow.nautikus.io/v1alpha1 Dag manifests keep working; api/v1beta1 is
+// now the storage version and gains fields (like TaskSpec.Workspaces) this
+// package doesn't have. Dag converts to/from v1beta1 via ConvertTo/
+// ConvertFrom (dag_conversion.go), served through the webhook handler in
+// internal/webhook/conversion.
+// +kubebuilder:object:generate=true
+// +groupName=workflow.nautikus.io
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "workflow.nautikus.io", Version: "v1alpha1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// NextBackoff returns the delay before retrying the given attempt number
+// (0-indexed: the delay before the first retry uses attempt=0), computed as
+// min(InitialBackoff * BackoffFactor^attempt, MaxBackoff).
+func (p *RetryPolicy) NextBackoff(attempt int) time.Duration {
+	factor := p.BackoffFactor
+	if factor <= 0 {
+		factor = 1
+	}
+
+	backoff := float64(p.InitialBackoff.Duration) * math.Pow(factor, float64(attempt))
+	max := float64(p.MaxBackoff.Duration)
+	if max > 0 && backoff > max {
+		backoff = max
+	}
+	return time.Duration(backoff)
+}
+
+// IsRetryable reports whether err should be retried under this policy. A nil
+// or empty RetryableErrors list means every failure is retryable.
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if len(p.RetryableErrors) == 0 {
+		return true
+	}
+	msg := err.Error()
+	for _, substr := range p.RetryableErrors {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskRunSpec mirrors Tekton's CustomRun: it names the external controller
+// that should drive this task (Ref) and the parameters to pass it, without
+// Nautikus needing to know anything about that controller's own resource
+// shape. Contrast CustomTaskRef, which carries an opaque payload a
+// Nautikus-side ExternalResourceConnector itself interprets.
+type TaskRunSpec struct {
+	// Ref identifies the external controller that owns execution, by
+	// GroupVersionKind. Any controller watching that Kind can drive this
+	// TaskRun to completion just by setting Status.Conditions, without
+	// Nautikus shipping a connector for it.
+	Ref TaskRef `json:"ref"`
+	// Params are passed through to whatever controller reconciles Ref, the
+	// way Tekton CustomRun.Spec.Params are passed to custom task controllers.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// TaskRunStatus reports how the external controller is progressing. It
+// follows Tekton's CustomRun convention: the controller sets a condition of
+// Type "Succeeded" with Status True/False/Unknown to report completion,
+// failure, or in-progress.
+type TaskRunStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// TaskRun is a generic handoff object for TaskTypeRun tasks: Nautikus
+// creates one per task and waits for its Succeeded condition, so any
+// external controller can drive task completion by reconciling this one
+// CRD instead of Nautikus shipping a dedicated ExternalResourceConnector
+// for every backend.
+type TaskRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskRunSpec   `json:"spec,omitempty"`
+	Status TaskRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TaskRunList contains a list of TaskRun
+type TaskRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TaskRun{}, &TaskRunList{})
+}
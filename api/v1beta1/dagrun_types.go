@@ -0,0 +1,91 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TriggerType identifies what caused a DagRun to be created.
+type TriggerType string
+
+const (
+	// TriggerManual means a user submitted the DagRun directly.
+	TriggerManual TriggerType = "Manual"
+	// TriggerScheduled means a cron/schedule trigger created the DagRun.
+	TriggerScheduled TriggerType = "Scheduled"
+	// TriggerEvent means an external event (e.g. an upstream EventStore
+	// notification) created the DagRun.
+	TriggerEvent TriggerType = "Event"
+	// TriggerRetry means the DagRun was created to re-run a previously
+	// failed DagRun.
+	TriggerRetry TriggerType = "Retry"
+)
+
+// DagRef identifies the Dag a DagRun was created from, pinning the spec
+// generation it was submitted against so later edits to the Dag don't
+// retroactively change what an in-flight or historical run executed.
+type DagRef struct {
+	// Name of the referenced Dag, in the same namespace as the DagRun.
+	Name string `json:"name"`
+	// Generation of the Dag this run was submitted against.
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// DagRunSpec describes one execution instance of a Dag.
+type DagRunSpec struct {
+	// DagRef points at the Dag this run executes.
+	DagRef DagRef `json:"dagRef"`
+	// Trigger records what caused this run to be created.
+	Trigger TriggerType `json:"trigger"`
+	// Parameters are run-specific overrides made available to tasks
+	// (e.g. as environment variables), separate from the Dag's own spec.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// DagRunStatus aggregates the execution state of a DagRun. Unlike DagStatus,
+// this is never mutated by a later run: each DagRun is immutable history
+// once created.
+type DagRunStatus struct {
+	// State is the overall state of this run.
+	State TaskState `json:"state,omitempty"`
+
+	// Total, Succeeded, Failed, InProgress and Stopped count tasks in
+	// TaskStatuses by state, kept in sync as tasks transition so callers
+	// can report progress without walking TaskStatuses themselves.
+	Total      int `json:"total,omitempty"`
+	Succeeded  int `json:"succeeded,omitempty"`
+	Failed     int `json:"failed,omitempty"`
+	InProgress int `json:"inProgress,omitempty"`
+	Stopped    int `json:"stopped,omitempty"`
+
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	EndTime   *metav1.Time `json:"endTime,omitempty"`
+
+	TaskStatuses []TaskStatus `json:"taskStatuses,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// DagRun is the Schema for the dagruns API. Every submission of a Dag
+// produces a new, immutable DagRun, so past executions stay queryable (and
+// re-runnable) without losing prior results the way a status-only Dag does.
+type DagRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DagRunSpec   `json:"spec,omitempty"`
+	Status DagRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DagRunList contains a list of DagRun
+type DagRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DagRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DagRun{}, &DagRunList{})
+}
@@ -0,0 +1,24 @@
+// Package v1beta1 contains API Schema definitions for the workflow v1beta1
+// API group. It is the storage version: internal consumers (the controller,
+// pod.Executor, and the executor registry) work with these types directly,
+// and api/v1alpha1 converts to/from them via internal/webhook/conversion so
+// existing workflow.nautikus.io/v1alpha1 manifests keep working.
+// +kubebuilder:object:generate=true
+// +groupName=workflow.nautikus.io
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "workflow.nautikus.io", Version: "v1beta1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
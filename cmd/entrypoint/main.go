@@ -0,0 +1,149 @@
+// Command entrypoint is injected as a task Pod's actual container command,
+// Tekton entrypoint-rewriting style: it waits for any dependency marker
+// files named by -wait-for, runs the real task command, and leaves an
+// exit-code marker at -post-file so a sidecar (or a later step, once Pods
+// host more than one container) can observe completion without polling the
+// Pod's own status. No task wires -wait-for today — tasks are still one per
+// Pod, and the DAG controller already gates Pod creation on dependencies —
+// but the plumbing is in place for intra-pod step sequencing.
+//
+// It also surfaces whatever the task wrote under -results-dir so downstream
+// tasks can reference it via $(tasks.<name>.result.<key>) (see
+// internal/substitution): since the controller can't read a finished Pod's
+// filesystem directly, the results are printed as a single marker line on
+// stdout for pod.Executor to pick back out of the container's logs, the
+// same way OutputCapturer already does for BranchRule outputs.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// resultsMarkerPrefix tags the results JSON line in stdout so it can't be
+// confused with a task's own BranchRule output line (OutputCapturer reads
+// the log's last line; this is a distinct, explicitly-prefixed line instead
+// so the two conventions don't collide).
+const resultsMarkerPrefix = "__NAUTIKUS_RESULTS__"
+
+func main() {
+	var (
+		waitFor    string
+		waitDir    string
+		postFile   string
+		resultsDir string
+	)
+	flag.StringVar(&waitFor, "wait-for", "", "comma-separated marker names to wait for under -wait-dir before running the command")
+	flag.StringVar(&waitDir, "wait-dir", "/nautikus/wait", "directory containing dependency marker files")
+	flag.StringVar(&postFile, "post-file", "/nautikus/post/done", "file to write the command's exit code to once it finishes")
+	flag.StringVar(&resultsDir, "results-dir", "/nautikus/results", "directory the task writes result files to, one file per key")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "entrypoint: no command given")
+		os.Exit(1)
+	}
+
+	for _, name := range splitNonEmpty(waitFor, ",") {
+		waitForMarker(filepath.Join(waitDir, name))
+	}
+
+	exitCode := run(args)
+	writePostFile(postFile, exitCode)
+	printResults(resultsDir)
+	os.Exit(exitCode)
+}
+
+// run execs the real task command, streaming its stdio through unchanged,
+// and returns its exit code.
+func run(args []string) int {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "entrypoint: failed to run command: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// waitForMarker blocks until path exists.
+func waitForMarker(path string) {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// writePostFile leaves exitCode behind at path so anything watching the
+// shared volume can tell the command finished without inspecting the Pod.
+func writePostFile(path string, exitCode int) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "entrypoint: failed to create post-file dir: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d", exitCode)), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "entrypoint: failed to write post-file: %v\n", err)
+	}
+}
+
+// printResults reads every file directly under dir and prints them as a
+// single JSON object on one stdout line, prefixed with resultsMarkerPrefix.
+// A missing or empty dir means the task produced no results, which isn't an
+// error.
+func printResults(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	results := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "entrypoint: failed to read result %s: %v\n", entry.Name(), err)
+			continue
+		}
+		results[entry.Name()] = string(content)
+	}
+	if len(results) == 0 {
+		return
+	}
+
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "entrypoint: failed to encode results: %v\n", err)
+		return
+	}
+	fmt.Println(resultsMarkerPrefix + string(encoded))
+}
+
+func splitNonEmpty(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, sep) {
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+// Command script-fetch is the init-container entrypoint for Pod tasks whose
+// TaskSpec uses a ScriptRef: it resolves the referenced content-addressed
+// script against the backend named by its scheme and writes it to a path on
+// a volume shared with the main container, so the task Pod spec itself never
+// needs to carry the full script source.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kination/nautikus/internal/scriptstore"
+	"github.com/kination/nautikus/internal/scriptstore/filesystem"
+	"github.com/kination/nautikus/internal/scriptstore/registry"
+)
+
+func main() {
+	var (
+		storeRef string
+		digest   string
+		out      string
+	)
+	flag.StringVar(&storeRef, "store-ref", "", "storeRef to fetch (file://... or registry://...)")
+	flag.StringVar(&digest, "digest", "", "expected content digest, verified after fetch")
+	flag.StringVar(&out, "out", "/nautikus/script", "path to write the fetched script to")
+	flag.Parse()
+
+	if storeRef == "" {
+		fmt.Fprintln(os.Stderr, "script-fetch: -store-ref is required")
+		os.Exit(1)
+	}
+
+	store, err := backendFor(storeRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "script-fetch: %v\n", err)
+		os.Exit(1)
+	}
+
+	content, err := store.Get(context.Background(), storeRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "script-fetch: failed to fetch %s: %v\n", storeRef, err)
+		os.Exit(1)
+	}
+
+	if digest != "" {
+		if got := scriptstore.Digest(content); got != digest {
+			fmt.Fprintf(os.Stderr, "script-fetch: digest mismatch for %s: want %s, got %s\n", storeRef, digest, got)
+			os.Exit(1)
+		}
+	}
+
+	if err := os.WriteFile(out, content, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "script-fetch: failed to write %s: %v\n", out, err)
+		os.Exit(1)
+	}
+}
+
+// backendFor picks the scriptstore.Store implementation matching storeRef's
+// scheme. Backends are referenced directly rather than through a
+// store.Register-style factory since the set of schemes is small and fixed.
+func backendFor(storeRef string) (scriptstore.Store, error) {
+	switch {
+	case strings.HasPrefix(storeRef, "file://"):
+		dir := os.Getenv("NAUTIKUS_SCRIPT_STORE_DIR")
+		if dir == "" {
+			dir = "/var/lib/nautikus/scripts"
+		}
+		return filesystem.New(dir)
+	case strings.HasPrefix(storeRef, "registry://"):
+		repo := os.Getenv("NAUTIKUS_SCRIPT_STORE_REPO")
+		if repo == "" {
+			return nil, fmt.Errorf("NAUTIKUS_SCRIPT_STORE_REPO must be set to fetch %s", storeRef)
+		}
+		return registry.New(repo), nil
+	default:
+		return nil, fmt.Errorf("unrecognized storeRef scheme: %s", storeRef)
+	}
+}
@@ -0,0 +1,122 @@
+// Package branch evaluates the small boolean expression language used by
+// workflowv1.BranchRule. It is intentionally not a full CEL implementation:
+// it supports exactly what branch conditions need, referencing a single
+// upstream task's state or one of its outputs.
+package branch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// Evaluate reports whether expression holds against statuses, a snapshot of
+// every task's current TaskStatus keyed by task name. Supported forms:
+//
+//	tasks.<name>.state == "Completed"
+//	tasks.<name>.outputs.<key> == "value"
+//	tasks.<name>.outputs.<key> > 100
+//
+// Clauses may be combined with "&&"; every clause must hold for the overall
+// expression to evaluate true. Comparable operators are ==, !=, >, >=, <, <=
+// — ordering operators parse both sides as numbers.
+func Evaluate(expression string, statuses map[string]workflowv1.TaskStatus) (bool, error) {
+	for _, clause := range strings.Split(expression, "&&") {
+		ok, err := evaluateClause(strings.TrimSpace(clause), statuses)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+var operators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+func evaluateClause(clause string, statuses map[string]workflowv1.TaskStatus) (bool, error) {
+	op, left, right, err := splitOperator(clause)
+	if err != nil {
+		return false, err
+	}
+
+	actual, err := resolveRef(left, statuses)
+	if err != nil {
+		return false, err
+	}
+	expected := strings.Trim(strings.TrimSpace(right), `"`)
+
+	switch op {
+	case "==":
+		return actual == expected, nil
+	case "!=":
+		return actual != expected, nil
+	case ">", ">=", "<", "<=":
+		return compareNumeric(op, actual, expected)
+	default:
+		return false, fmt.Errorf("branch: unsupported operator %q in clause %q", op, clause)
+	}
+}
+
+func splitOperator(clause string) (op, left, right string, err error) {
+	for _, candidate := range operators {
+		if i := strings.Index(clause, candidate); i >= 0 {
+			return candidate, clause[:i], clause[i+len(candidate):], nil
+		}
+	}
+	return "", "", "", fmt.Errorf("branch: no supported operator found in clause %q", clause)
+}
+
+// resolveRef looks up tasks.<name>.state or tasks.<name>.outputs.<key>
+// against statuses.
+func resolveRef(ref string, statuses map[string]workflowv1.TaskStatus) (string, error) {
+	ref = strings.TrimSpace(ref)
+	parts := strings.SplitN(ref, ".", 4)
+	if len(parts) < 3 || parts[0] != "tasks" {
+		return "", fmt.Errorf("branch: %q is not a tasks.<name>.state or tasks.<name>.outputs.<key> reference", ref)
+	}
+
+	status, ok := statuses[parts[1]]
+	if !ok {
+		return "", fmt.Errorf("branch: no task status for %q", parts[1])
+	}
+
+	switch parts[2] {
+	case "state":
+		return string(status.State), nil
+	case "outputs":
+		if len(parts) != 4 {
+			return "", fmt.Errorf("branch: %q is missing an outputs key", ref)
+		}
+		return status.Outputs[parts[3]], nil
+	default:
+		return "", fmt.Errorf("branch: %q must reference .state or .outputs.<key>", ref)
+	}
+}
+
+func compareNumeric(op, actual, expected string) (bool, error) {
+	a, err := strconv.ParseFloat(strings.TrimSpace(actual), 64)
+	if err != nil {
+		return false, fmt.Errorf("branch: %q is not numeric: %w", actual, err)
+	}
+	b, err := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+	if err != nil {
+		return false, fmt.Errorf("branch: %q is not numeric: %w", expected, err)
+	}
+
+	switch op {
+	case ">":
+		return a > b, nil
+	case ">=":
+		return a >= b, nil
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	default:
+		return false, fmt.Errorf("branch: unsupported numeric operator %q", op)
+	}
+}
@@ -0,0 +1,72 @@
+package branch
+
+import (
+	"testing"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+func TestEvaluate_State(t *testing.T) {
+	statuses := map[string]workflowv1.TaskStatus{
+		"check": {Name: "check", State: workflowv1.StateCompleted},
+	}
+
+	ok, err := Evaluate(`tasks.check.state == "Completed"`, statuses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("expected clause to be true")
+	}
+
+	ok, err = Evaluate(`tasks.check.state == "Failed"`, statuses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected clause to be false")
+	}
+}
+
+func TestEvaluate_NumericOutput(t *testing.T) {
+	statuses := map[string]workflowv1.TaskStatus{
+		"check_size": {Name: "check_size", Outputs: map[string]string{"count": "150"}},
+	}
+
+	ok, err := Evaluate(`tasks.check_size.outputs.count > 100`, statuses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("expected 150 > 100 to be true")
+	}
+
+	ok, err = Evaluate(`tasks.check_size.outputs.count <= 100`, statuses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected 150 <= 100 to be false")
+	}
+}
+
+func TestEvaluate_CombinedClauses(t *testing.T) {
+	statuses := map[string]workflowv1.TaskStatus{
+		"check": {Name: "check", State: workflowv1.StateCompleted, Outputs: map[string]string{"env": "prod"}},
+	}
+
+	ok, err := Evaluate(`tasks.check.state == "Completed" && tasks.check.outputs.env == "prod"`, statuses)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("expected combined clause to be true")
+	}
+}
+
+func TestEvaluate_UnknownTask(t *testing.T) {
+	_, err := Evaluate(`tasks.missing.state == "Completed"`, map[string]workflowv1.TaskStatus{})
+	if err == nil {
+		t.Error("expected error for unknown task reference")
+	}
+}
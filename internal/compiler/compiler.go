@@ -2,22 +2,65 @@ package compiler
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// DagSource describes one directory to scan for DAG definitions.
 type DagSource struct {
 	Name     string `yaml:"name"`
 	Location string `yaml:"location"`
+
+	// SandboxImage runs each file in this source inside the given
+	// container image instead of directly on the host. Required for
+	// untrusted sources (e.g. PR contributions); empty runs on the host,
+	// which should only be used for trusted, first-party DAGs.
+	SandboxImage string `yaml:"sandboxImage,omitempty"`
+	// CPULimit and MemoryLimit are passed to the container runtime as
+	// `--cpus`/`--memory` to bound resource usage per compiled file.
+	CPULimit    string `yaml:"cpuLimit,omitempty"`
+	MemoryLimit string `yaml:"memoryLimit,omitempty"`
 }
 
+// CompileStatus is the outcome of compiling a single source file.
+type CompileStatus string
+
+const (
+	CompileStatusOK      CompileStatus = "ok"
+	CompileStatusError   CompileStatus = "error"
+	CompileStatusSkipped CompileStatus = "skipped"
+)
+
+// CompileResult is one entry of the structured compile report, written
+// alongside the generated JSON so CI can gate merges on it.
+type CompileResult struct {
+	Source   string        `json:"source"`
+	Output   string        `json:"output,omitempty"`
+	Stderr   string        `json:"stderr,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Status   CompileStatus `json:"status"`
+}
+
+// sandboxRuntime is the container CLI used to sandbox untrusted sources.
+// Overridable in tests / for Podman users.
+var sandboxRuntime = "docker"
+
+// maxParallelism caps how many files are compiled at once across all
+// sources. Defaults to the host's CPU count.
+var maxParallelism = runtime.NumCPU()
+
 func CompileDags(configPath string, outputDir string) error {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -29,11 +72,17 @@ func CompileDags(configPath string, outputDir string) error {
 		return fmt.Errorf("yaml parse error: %w", err)
 	}
 
-	// Generate output directory if not exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output dir: %w", err)
 	}
 
+	type job struct {
+		src  DagSource
+		path string
+		kind string // "python" or "go"
+	}
+
+	var jobs []job
 	for _, src := range sources {
 		fmt.Printf("📂 Scanning source: %s (%s)\n", src.Name, src.Location)
 
@@ -44,53 +93,144 @@ func CompileDags(configPath string, outputDir string) error {
 			if d.IsDir() {
 				return nil
 			}
-
-			// support python and go file
-			ext := filepath.Ext(d.Name())
-			switch ext {
+			switch filepath.Ext(d.Name()) {
 			case ".py":
-				return generateJSON("python3", []string{path}, path, outputDir)
+				jobs = append(jobs, job{src: src, path: path, kind: "python"})
 			case ".go":
-				return generateJSON("go", []string{"run", path}, path, outputDir)
+				jobs = append(jobs, job{src: src, path: path, kind: "go"})
 			}
 			return nil
 		})
-
 		if err != nil {
 			return fmt.Errorf("walk error in %s: %w", src.Location, err)
 		}
 	}
+
+	// Process sources concurrently with a bounded worker pool so a large
+	// repository of untrusted DAG sources doesn't serialize behind a single
+	// `go run`/`python3` invocation.
+	results := make([]CompileResult, len(jobs))
+	sem := make(chan struct{}, maxParallelism)
+	var wg sync.WaitGroup
+
+	for i, j := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, j job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = compileOne(j.src, j.path, j.kind, outputDir)
+		}(i, j)
+	}
+	wg.Wait()
+
+	if err := writeReport(outputDir, results); err != nil {
+		return fmt.Errorf("failed to write compile report: %w", err)
+	}
+
+	for _, r := range results {
+		if r.Status == CompileStatusError {
+			return fmt.Errorf("compilation failed for one or more sources, see %s", filepath.Join(outputDir, "report.json"))
+		}
+	}
 	return nil
 }
 
-// generateJSON is a helper function that runs a command and saves the standard output to a file.
-func generateJSON(cmdName string, cmdArgs []string, srcPath string, outputDir string) error {
-	cmd := exec.Command(cmdName, cmdArgs...)
+// compileOne compiles a single source file and returns its structured
+// result, never returning an error directly so one bad file doesn't abort
+// the rest of the worker pool.
+func compileOne(src DagSource, path, kind, outputDir string) CompileResult {
+	var cmdName string
+	var cmdArgs []string
+
+	switch kind {
+	case "python":
+		cmdName, cmdArgs = "python3", []string{path}
+	case "go":
+		cmdName, cmdArgs = "go", []string{"run", path}
+	}
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	if src.SandboxImage != "" {
+		cmdName, cmdArgs = sandboxCommand(src, path, cmdName, cmdArgs)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("execution failed for %s\n[Stderr]: %s", srcPath, stderr.String())
+	start := time.Now()
+	output, stderr, err := runCommand(cmdName, cmdArgs)
+	duration := time.Since(start)
+
+	result := CompileResult{Source: path, Stderr: stderr, Duration: duration}
+
+	if err != nil {
+		result.Status = CompileStatusError
+		log.Printf("❌ Compile failed for %s: %v\n[Stderr]: %s", path, err, stderr)
+		return result
 	}
 
-	output := stdout.Bytes()
 	if len(output) == 0 {
-		log.Printf("⚠️  Warning: %s produced no output. Skipping.", srcPath)
-		return nil
+		result.Status = CompileStatusSkipped
+		log.Printf("⚠️  Warning: %s produced no output. Skipping.", path)
+		return result
 	}
 
-	// convert output to file (blablabla.py -> blablabla.json)
-	baseName := filepath.Base(srcPath)
+	baseName := filepath.Base(path)
 	ext := filepath.Ext(baseName)
 	fileName := strings.TrimSuffix(baseName, ext) + ".json"
 	savePath := filepath.Join(outputDir, fileName)
 
 	if err := os.WriteFile(savePath, output, 0644); err != nil {
-		return fmt.Errorf("write error: %w", err)
+		result.Status = CompileStatusError
+		result.Stderr = err.Error()
+		return result
 	}
 
+	result.Status = CompileStatusOK
+	result.Output = savePath
 	fmt.Printf("   ✨ Compiled: %s -> %s\n", baseName, fileName)
-	return nil
+	return result
+}
+
+// sandboxCommand wraps cmdName/cmdArgs so it runs inside src.SandboxImage
+// with the configured resource limits, mounting the source file read-only.
+func sandboxCommand(src DagSource, path, cmdName string, cmdArgs []string) (string, []string) {
+	mountDir := filepath.Dir(path)
+
+	args := []string{
+		"run", "--rm",
+		"--network", "none",
+		"-v", fmt.Sprintf("%s:%s:ro", mountDir, mountDir),
+		"-w", mountDir,
+	}
+	if src.CPULimit != "" {
+		args = append(args, "--cpus", src.CPULimit)
+	}
+	if src.MemoryLimit != "" {
+		args = append(args, "--memory", src.MemoryLimit)
+	}
+	args = append(args, src.SandboxImage, cmdName)
+	args = append(args, cmdArgs...)
+
+	return sandboxRuntime, args
+}
+
+func runCommand(cmdName string, cmdArgs []string) ([]byte, string, error) {
+	cmd := exec.CommandContext(context.Background(), cmdName, cmdArgs...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, stderr.String(), fmt.Errorf("execution failed: %w", err)
+	}
+	return stdout.Bytes(), stderr.String(), nil
+}
+
+// writeReport persists the structured compile report alongside the
+// generated JSON manifests so CI can gate merges on it.
+func writeReport(outputDir string, results []CompileResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, "report.json"), data, 0644)
 }
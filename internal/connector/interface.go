@@ -5,13 +5,17 @@ package connector
 import (
 	"context"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ExternalResourceConnector defines the interface for K8s CR-based connectors.
 // Used for Kubeflow, Katib, KServe, Ray, etc.
+//
+// Connectors intended for dispatch via workflowv1.TaskSpec.Ref are registered
+// under the key "<apiVersion>/<kind>" (e.g. "kubeflow.org/v1/PyTorchJob") so
+// the Runner can look them up directly from the TaskRef.
 type ExternalResourceConnector interface {
 	// Type returns the task type this connector handles (e.g., "kubeflow/pytorchjob")
 	Type() string
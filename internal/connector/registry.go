@@ -2,7 +2,10 @@ package connector
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // Registry manages connector registration and lookup
@@ -97,3 +100,47 @@ func (r *Registry) CloudTypes() []string {
 	}
 	return types
 }
+
+// RegisteredGVKs returns the GroupVersionKind of every registered external
+// connector, derived from its "<apiVersion>/<kind>" registration key. The
+// ConnectorReconciler's manager setup uses this to check (via discovery)
+// which connectors' CRDs are actually installed before starting an informer
+// for them, so a cluster missing e.g. Kubeflow's CRDs can still skip that
+// connector instead of crashing at startup.
+func (r *Registry) RegisteredGVKs() []schema.GroupVersionKind {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	gvks := make([]schema.GroupVersionKind, 0, len(r.externalConnectors))
+	for key := range r.externalConnectors {
+		gvk, ok := parseConnectorKey(key)
+		if !ok {
+			continue
+		}
+		gvks = append(gvks, gvk)
+	}
+	return gvks
+}
+
+// Key builds the "<apiVersion>/<kind>" registration key for gvk, the
+// inverse of parseConnectorKey. The ConnectorReconciler uses it to look up
+// the connector for a GVK it was handed by RegisteredGVKs.
+func Key(gvk schema.GroupVersionKind) string {
+	return fmt.Sprintf("%s/%s", gvk.GroupVersion().String(), gvk.Kind)
+}
+
+// parseConnectorKey splits a "<apiVersion>/<kind>" registration key back
+// into a GroupVersionKind. apiVersion itself may contain a slash (grouped
+// versions like "kubeflow.org/v1"), so only the final segment is the kind.
+func parseConnectorKey(key string) (schema.GroupVersionKind, bool) {
+	i := strings.LastIndex(key, "/")
+	if i < 0 {
+		return schema.GroupVersionKind{}, false
+	}
+	apiVersion, kind := key[:i], key[i+1:]
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, false
+	}
+	return gv.WithKind(kind), true
+}
@@ -7,7 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 )
 
 // MockExternalConnector is a mock implementation for testing
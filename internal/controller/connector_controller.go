@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/connector"
+)
+
+// EnableConnectorsEnv gates whether SetupConnectorReconcilers registers
+// anything at all. Clusters that haven't installed any connector CRDs
+// (Kubeflow, Ray, KServe, ...) can leave it unset and the manager starts
+// with zero connector informers instead of failing on a missing schema.
+const EnableConnectorsEnv = "ENABLE_CONNECTORS"
+
+// ConnectorReconciler watches the unstructured CRs an
+// ExternalResourceConnector builds for a single GroupVersionKind and
+// reflects their status onto the owning DagRun's TaskStatus. It runs
+// independently of DagReconciler so a slow or missing connector CRD never
+// blocks the main DAG reconcile loop.
+// +kubebuilder:rbac:groups=workflow.nautikus.io,resources=dagruns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=workflow.nautikus.io,resources=dagruns/status,verbs=get;update;patch
+type ConnectorReconciler struct {
+	client.Client
+	Connectors *connector.Registry
+	GVK        schema.GroupVersionKind
+}
+
+func (r *ConnectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(r.GVK)
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	dagName := obj.GetLabels()["dag"]
+	taskName := obj.GetLabels()["task"]
+	runName := obj.GetLabels()["dagrun"]
+	if dagName == "" || taskName == "" || runName == "" {
+		logger.Info("skipping connector resource missing dag/task/dagrun labels", "name", obj.GetName())
+		return ctrl.Result{}, nil
+	}
+
+	var dag workflowv1.Dag
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: dagName}, &dag); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	task, err := findTask(&dag, taskName)
+	if err != nil {
+		logger.Error(err, "connector resource references unknown task", "dag", dagName)
+		return ctrl.Result{}, nil
+	}
+
+	conn, err := r.Connectors.GetExternal(connector.Key(r.GVK))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	state, err := conn.GetStatus(ctx, r.Client, task, &dag)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var run workflowv1.DagRun
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: runName}, &run); err != nil {
+		if errors.IsNotFound(err) {
+			// Nothing in this reconciler's control path creates the DagRun
+			// named by the "dagrun" label itself — it's expected to already
+			// exist, produced by whatever submitted this execution. Log
+			// instead of silently discarding the status update so a
+			// permanently-missing DagRun (e.g. nothing upstream constructs
+			// one yet) doesn't look identical to "nothing to sync".
+			logger.Info("connector resource references a DagRun that does not exist, skipping status sync", "dagrun", runName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	setTaskState(&run.Status, taskName, state)
+	if err := r.Status().Update(ctx, &run); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// findTask looks up a task by name within dag's spec.
+func findTask(dag *workflowv1.Dag, name string) (*workflowv1.TaskSpec, error) {
+	for i := range dag.Spec.Tasks {
+		if dag.Spec.Tasks[i].Name == name {
+			return &dag.Spec.Tasks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("dag %s has no task named %s", dag.Name, name)
+}
+
+// setTaskState updates (or appends) the TaskStatus entry for taskName.
+func setTaskState(status *workflowv1.DagRunStatus, taskName string, state workflowv1.TaskState) {
+	for i := range status.TaskStatuses {
+		if status.TaskStatuses[i].Name == taskName {
+			status.TaskStatuses[i].State = state
+			return
+		}
+	}
+	status.TaskStatuses = append(status.TaskStatuses, workflowv1.TaskStatus{Name: taskName, State: state})
+}
+
+// SetupWithManager sets up the controller with the Manager, watching only
+// objects of r.GVK.
+func (r *ConnectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(r.GVK)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(fmt.Sprintf("connector-%s-%s", r.GVK.Kind, r.GVK.Version)).
+		For(obj).
+		Complete(r)
+}
+
+// SetupConnectorReconcilers registers a ConnectorReconciler for every GVK
+// connectors has a registered ExternalResourceConnector for, skipping (with
+// a warning, not a startup failure) any whose CRD discovery can't find
+// installed in the cluster. The whole thing is a no-op unless
+// ENABLE_CONNECTORS=true, so minimal clusters that never install
+// Kubeflow/Ray/KServe CRDs can still run the operator.
+func SetupConnectorReconcilers(mgr ctrl.Manager, connectors *connector.Registry, dc discovery.DiscoveryInterface) error {
+	if os.Getenv(EnableConnectorsEnv) != "true" {
+		return nil
+	}
+
+	logger := ctrl.Log.WithName("connector-setup")
+	for _, gvk := range connectors.RegisteredGVKs() {
+		installed, err := crdInstalled(dc, gvk)
+		if err != nil {
+			return fmt.Errorf("checking discovery for %s: %w", gvk, err)
+		}
+		if !installed {
+			logger.Info("skipping connector: CRD not installed in cluster", "gvk", gvk.String())
+			continue
+		}
+
+		r := &ConnectorReconciler{Client: mgr.GetClient(), Connectors: connectors, GVK: gvk}
+		if err := r.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up connector reconciler for %s: %w", gvk, err)
+		}
+	}
+	return nil
+}
+
+// crdInstalled checks the API server's discovery document for gvk, so
+// SetupConnectorReconcilers can skip connectors whose CRDs were never
+// installed instead of starting an informer that would error forever.
+func crdInstalled(dc discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (bool, error) {
+	resources, err := dc.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, res := range resources.APIResources {
+		if res.Kind == gvk.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
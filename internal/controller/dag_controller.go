@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -14,17 +15,25 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	workflowv1 "github.com/kination/pequod/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/history"
+	"github.com/kination/nautikus/internal/substitution"
 )
 
 // DagReconciler reconciles a Dag object
-// +kubebuilder:rbac:groups=workflow.pequod.io,resources=dags,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=workflow.pequod.io,resources=dags/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=workflow.pequod.io,resources=dags/finalizers,verbs=update
+// +kubebuilder:rbac:groups=workflow.nautikus.io,resources=dags,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=workflow.nautikus.io,resources=dags/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=workflow.nautikus.io,resources=dags/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 type DagReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// History, if set, records one history.Execution per Dag (keyed by
+	// Dag.Name) and keeps its counters in sync on every reconcile. Nil
+	// disables history recording entirely.
+	History history.HistoryStore
 }
 
 func (r *DagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -35,18 +44,57 @@ func (r *DagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	if err := r.Get(ctx, req.NamespacedName, &dag); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	isNewExecution := dag.Status.State == ""
 
-	// Sync current running Pod and Status
-	if err := r.syncStatus(ctx, &dag); err != nil {
+	// Sync current running Pod and Status. retryAfter is non-zero when
+	// syncStatus just created a retry attempt Pod for a failed task and
+	// wants to be woken up once its TaskSpec.RetryBackoff elapses, rather
+	// than on the default immediate requeue.
+	retryAfter, err := r.syncStatus(ctx, &dag)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Reject $(tasks...) references to tasks that don't exist. This repo
+	// has no admission webhook to run this ahead of persisting the Dag, so
+	// it's enforced here instead: a Dag with a bad reference is marked
+	// Failed before any further task of it is scheduled.
+	if !isTerminalState(dag.Status.State) {
+		if err := substitution.ValidateReferences(&dag); err != nil {
+			log.Error(err, "Dag has an invalid task reference")
+			dag.Status.State = workflowv1.StateFailed
+			if statusErr := r.Status().Update(ctx, &dag); statusErr != nil {
+				return ctrl.Result{}, statusErr
+			}
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Find next task to run (dependency check)
 	nextTasks := r.getNextTasks(&dag)
 
 	// Create Pod
 	for _, task := range nextTasks {
-		pod := r.buildPod(&dag, task)
+		pod, err := r.buildPod(&dag, task, fmt.Sprintf("%s-%s-0", dag.Name, task.Name))
+		if err != nil {
+			// getNextTasks already recorded a Pending TaskStatus for task;
+			// a type buildPod can't run as a Pod must fail it loudly here
+			// rather than leave that Pending status pointing at a Pod that
+			// was never created.
+			log.Error(err, "cannot build Pod for task, failing it", "Task.Name", task.Name)
+			failTask(&dag, task.Name, err)
+			continue
+		}
+
+		if cm := r.buildScriptConfigMap(&dag, task); cm != nil {
+			if err := controllerutil.SetControllerReference(&dag, cm, r.Scheme); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.Create(ctx, cm); err != nil && !errors.IsAlreadyExists(err) {
+				return ctrl.Result{}, err
+			}
+		}
+
 		// Set owner reference (Pod will be deleted when DAG is deleted)
 		if err := controllerutil.SetControllerReference(&dag, pod, r.Scheme); err != nil {
 			return ctrl.Result{}, err
@@ -58,11 +106,17 @@ func (r *DagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		}
 	}
 
+	r.recordHistory(ctx, &dag, isNewExecution)
+
 	// Update Status
 	if err := r.Status().Update(ctx, &dag); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if retryAfter > 0 {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
 	// If DAG is not finished, continue Reconcile
 	if dag.Status.State == workflowv1.StateRunning {
 		return ctrl.Result{Requeue: true}, nil
@@ -71,8 +125,10 @@ func (r *DagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	return ctrl.Result{}, nil
 }
 
-// Sync Pod status to DAG status
-func (r *DagReconciler) syncStatus(ctx context.Context, dag *workflowv1.Dag) error {
+// Sync Pod status to DAG status. Returns a non-zero duration when a task
+// just started a retry attempt and the caller should requeue after that
+// instead of on its usual immediate/default cadence.
+func (r *DagReconciler) syncStatus(ctx context.Context, dag *workflowv1.Dag) (time.Duration, error) {
 	// Initialize map
 	if dag.Status.TaskStatuses == nil {
 		dag.Status.TaskStatuses = []workflowv1.TaskStatus{}
@@ -82,6 +138,9 @@ func (r *DagReconciler) syncStatus(ctx context.Context, dag *workflowv1.Dag) err
 	if dag.Status.State == "" {
 		dag.Status.State = workflowv1.StateRunning
 	}
+	if isTerminalState(dag.Status.State) {
+		return 0, nil
+	}
 
 	// Sync actual Pod status
 	statusMap := make(map[string]*workflowv1.TaskStatus)
@@ -90,7 +149,9 @@ func (r *DagReconciler) syncStatus(ctx context.Context, dag *workflowv1.Dag) err
 		statusMap[t.Name] = t
 	}
 
-	for _, taskSpec := range dag.Spec.Tasks {
+	var retryAfter time.Duration
+	allTasks := append(append([]workflowv1.TaskSpec{}, dag.Spec.Tasks...), dag.Spec.Finally...)
+	for _, taskSpec := range allTasks {
 		// Skip if already completed or failed
 		currentStatus, exists := statusMap[taskSpec.Name]
 		if !exists {
@@ -108,35 +169,131 @@ func (r *DagReconciler) syncStatus(ctx context.Context, dag *workflowv1.Dag) err
 				// Skip if Pod not found
 				continue
 			}
-			return err
+			return 0, err
 		}
 
 		// Update Pod status
-		if pod.Status.Phase == corev1.PodSucceeded {
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
 			currentStatus.State = workflowv1.StateCompleted
-		} else if pod.Status.Phase == corev1.PodFailed {
-			currentStatus.State = workflowv1.StateFailed
-			dag.Status.State = workflowv1.StateFailed // If one task fails, mark DAG as failed
-		} else {
+		case corev1.PodFailed:
+			backoff, retried, err := r.retryTask(ctx, dag, taskSpec, currentStatus, pod)
+			if err != nil {
+				return 0, err
+			}
+			if retried {
+				retryAfter = backoff
+			} else {
+				currentStatus.State = workflowv1.StateFailed
+			}
+		default:
 			currentStatus.State = workflowv1.StateRunning
 		}
 	}
-	return nil
+	return retryAfter, nil
+}
+
+// retryTask is called from syncStatus once task's current attempt Pod has
+// entered PodFailed. It records the failed attempt in status.AttemptHistory,
+// and — while task.Retries and the DAG's overall Spec.RetryBudget both still
+// allow it — deletes the failed Pod and creates the next attempt, returning
+// the backoff the caller should requeue after. Returns retried=false once
+// either budget is exhausted, leaving the caller to mark the task Failed.
+func (r *DagReconciler) retryTask(ctx context.Context, dag *workflowv1.Dag, task workflowv1.TaskSpec, status *workflowv1.TaskStatus, pod *corev1.Pod) (time.Duration, bool, error) {
+	status.AttemptHistory = append(status.AttemptHistory, attemptFromPod(pod))
+
+	budgetExhausted := dag.Spec.RetryBudget > 0 && dag.Status.RetriesUsed >= dag.Spec.RetryBudget
+	if len(status.AttemptHistory) > task.Retries || budgetExhausted {
+		return 0, false, nil
+	}
+
+	if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+		return 0, false, err
+	}
+
+	attempt := len(status.AttemptHistory)
+	podName := fmt.Sprintf("%s-%s-%d", dag.Name, task.Name, attempt)
+
+	if cm := r.buildScriptConfigMap(dag, task); cm != nil {
+		if err := controllerutil.SetControllerReference(dag, cm, r.Scheme); err != nil {
+			return 0, false, err
+		}
+		if err := r.Create(ctx, cm); err != nil && !errors.IsAlreadyExists(err) {
+			return 0, false, err
+		}
+	}
+
+	newPod, err := r.buildPod(dag, task, podName)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := controllerutil.SetControllerReference(dag, newPod, r.Scheme); err != nil {
+		return 0, false, err
+	}
+	if err := r.Create(ctx, newPod); err != nil {
+		return 0, false, err
+	}
+
+	status.PodName = podName
+	status.State = workflowv1.StatePending
+	dag.Status.RetriesUsed++
+	return task.RetryBackoff.Duration, true, nil
 }
 
-// Find next task to run (dependency check)
+// attemptFromPod builds the AttemptStatus recorded for a task's just-failed
+// Pod, pulling its exit code and finish time from the task-runner
+// container's terminated state when the pod object carries one.
+func attemptFromPod(pod *corev1.Pod) workflowv1.AttemptStatus {
+	attempt := workflowv1.AttemptStatus{
+		PodName:   pod.Name,
+		StartTime: pod.Status.StartTime,
+		State:     workflowv1.StateFailed,
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			attempt.ExitCode = cs.State.Terminated.ExitCode
+			finishedAt := cs.State.Terminated.FinishedAt
+			attempt.FinishTime = &finishedAt
+			break
+		}
+	}
+	return attempt
+}
+
+// Find next task to run (dependency check), including dag.Spec.Finally
+// tasks once every dag.Spec.Tasks has reached a terminal state.
 func (r *DagReconciler) getNextTasks(dag *workflowv1.Dag) []workflowv1.TaskSpec {
 	var nextTasks []workflowv1.TaskSpec
 
-	// Initialize status map
+	// Initialize status maps: statusMap for quick state lookups,
+	// statusesByName for $(tasks...) substitution (see internal/substitution),
+	// which also needs each task's captured Results.
 	statusMap := make(map[string]workflowv1.TaskState)
+	statusesByName := make(map[string]workflowv1.TaskStatus)
 	for _, s := range dag.Status.TaskStatuses {
 		statusMap[s.Name] = s.State
+		statusesByName[s.Name] = s
+	}
+
+	mainFailed := false
+	for _, s := range dag.Status.TaskStatuses {
+		if s.State == workflowv1.StateFailed {
+			mainFailed = true
+			break
+		}
 	}
 
+	// Once a task has failed, tasks downstream of it can never be
+	// scheduled, so only the main tasks already started must be terminal.
+	mainDone := true
 	for _, task := range dag.Spec.Tasks {
+		state, scheduled := statusMap[task.Name]
+		if (scheduled && !isTerminalState(state)) || (!scheduled && !mainFailed) {
+			mainDone = false
+		}
+
 		// Skip if already running or completed
-		if state, ok := statusMap[task.Name]; ok && state != "" {
+		if scheduled && state != "" {
 			continue
 		}
 
@@ -149,23 +306,159 @@ func (r *DagReconciler) getNextTasks(dag *workflowv1.Dag) []workflowv1.TaskSpec
 			}
 		}
 
-		if allDepsCompleted {
-			nextTasks = append(nextTasks, task)
-			// Prevent duplicate execution by adding status to Pending (actual update at Reconcile end)
+		if !allDepsCompleted {
+			continue
+		}
+
+		// Resolve any $(tasks...) references before scheduling; an
+		// unresolved one means a dependency hasn't reported its
+		// result/status yet, so defer this task to a later reconcile
+		// rather than run it with a literal "$(...)" in its command.
+		resolved, ok := substitution.SubstituteTask(task, statusesByName, substitution.Options{})
+		if !ok {
+			continue
+		}
+
+		nextTasks = append(nextTasks, resolved)
+		mainDone = false // a main task started in this pass is still running
+		// Prevent duplicate execution by adding status to Pending (actual update at Reconcile end)
+		dag.Status.TaskStatuses = append(dag.Status.TaskStatuses, workflowv1.TaskStatus{
+			Name:    task.Name,
+			State:   workflowv1.StatePending,
+			PodName: fmt.Sprintf("%s-%s-0", dag.Name, task.Name), // Pod name rule: <dag>-<task>-<attempt>
+		})
+	}
+
+	if !mainDone {
+		return nextTasks
+	}
+	if len(dag.Spec.Finally) == 0 {
+		dag.Status.State = finalDagState(mainFailed)
+		return nextTasks
+	}
+
+	// Every main task is terminal: schedule Finally tasks in parallel
+	// (once each, regardless of whether the main graph failed), and only
+	// flip the DAG to its final state once those are terminal too.
+	finallyDone := true
+	for _, task := range dag.Spec.Finally {
+		state, scheduled := statusMap[task.Name]
+		if !scheduled {
+			finallyDone = false
+			task = withDagStatusEnv(task, mainFailed)
+			resolved, ok := substitution.SubstituteTask(task, statusesByName, substitution.Options{
+				Finally:    true,
+				MainFailed: mainFailed,
+			})
+			if !ok {
+				continue
+			}
+			nextTasks = append(nextTasks, resolved)
 			dag.Status.TaskStatuses = append(dag.Status.TaskStatuses, workflowv1.TaskStatus{
 				Name:    task.Name,
 				State:   workflowv1.StatePending,
-				PodName: fmt.Sprintf("%s-%s", dag.Name, task.Name), // Pod name rule
+				PodName: fmt.Sprintf("%s-%s-0", dag.Name, task.Name),
 			})
+			continue
+		}
+		if !isTerminalState(state) {
+			finallyDone = false
 		}
 	}
+
+	if finallyDone {
+		dag.Status.State = finalDagState(mainFailed)
+	}
 	return nextTasks
 }
 
-// Convert TaskSpec to Pod (Bash, Python, Go Operator logic)
-func (r *DagReconciler) buildPod(dag *workflowv1.Dag, task workflowv1.TaskSpec) *corev1.Pod {
-	podName := fmt.Sprintf("%s-%s", dag.Name, task.Name)
+// isTerminalState reports whether s is a state a task won't leave on its own.
+func isTerminalState(s workflowv1.TaskState) bool {
+	return s == workflowv1.StateCompleted || s == workflowv1.StateFailed
+}
 
+// recordHistory is a no-op when r.History is nil. Otherwise it records a new
+// history.Execution the first time dag is seen (isNewExecution), then keeps
+// its counters (derived from dag.Status.TaskStatuses) in sync on every
+// subsequent call, setting EndTime once dag.Status.State reaches a terminal
+// state. Trigger is always recorded as TriggerManual: this reconciler acts
+// on Dag CRs directly rather than the DagRun CRs a scheduler.Trigger creates
+// (see internal/scheduler/trigger.go), so it has no other trigger info to
+// report.
+func (r *DagReconciler) recordHistory(ctx context.Context, dag *workflowv1.Dag, isNewExecution bool) {
+	if r.History == nil {
+		return
+	}
+	log := log.FromContext(ctx)
+
+	if isNewExecution {
+		if err := r.History.RecordExecutionStart(ctx, &history.Execution{
+			ID:        dag.Name,
+			DAGName:   dag.Name,
+			Status:    dag.Status.State,
+			StartTime: time.Now(),
+			Trigger:   workflowv1.TriggerManual,
+		}); err != nil {
+			log.Error(err, "failed to record execution start", "Dag.Name", dag.Name)
+		}
+	}
+
+	var counters history.ExecutionCounters
+	counters.Status = dag.Status.State
+	for _, ts := range dag.Status.TaskStatuses {
+		counters.Total++
+		switch ts.State {
+		case workflowv1.StateCompleted:
+			counters.Succeeded++
+		case workflowv1.StateFailed:
+			counters.Failed++
+		case workflowv1.StateRunning, workflowv1.StatePending:
+			counters.InProgress++
+		case workflowv1.StateSkipped:
+			counters.Stopped++
+		}
+	}
+	if isTerminalState(dag.Status.State) {
+		now := time.Now()
+		counters.EndTime = &now
+	}
+
+	if err := r.History.UpdateExecutionCounters(ctx, dag.Name, counters); err != nil {
+		log.Error(err, "failed to update execution counters", "Dag.Name", dag.Name)
+	}
+}
+
+// finalDagState is the DAG-level state once every task (main and Finally)
+// has reached a terminal state.
+func finalDagState(mainFailed bool) workflowv1.TaskState {
+	if mainFailed {
+		return workflowv1.StateFailed
+	}
+	return workflowv1.StateCompleted
+}
+
+// withDagStatusEnv returns a copy of task with NAUTIKUS_DAG_STATUS set, so
+// Finally tasks (cleanup, notifications) can tell whether the main graph
+// succeeded or failed.
+func withDagStatusEnv(task workflowv1.TaskSpec, mainFailed bool) workflowv1.TaskSpec {
+	env := make(map[string]string, len(task.Env)+1)
+	for k, v := range task.Env {
+		env[k] = v
+	}
+	if mainFailed {
+		env["NAUTIKUS_DAG_STATUS"] = "Failed"
+	} else {
+		env["NAUTIKUS_DAG_STATUS"] = "Succeeded"
+	}
+	task.Env = env
+	return task
+}
+
+// Convert TaskSpec to Pod (Bash, Python, Go Operator logic). podName is
+// supplied by the caller rather than derived here, since a retried task
+// needs its next attempt's name (see retryTask) while a first attempt uses
+// getNextTasks's attempt-0 name.
+func (r *DagReconciler) buildPod(dag *workflowv1.Dag, task workflowv1.TaskSpec, podName string) (*corev1.Pod, error) {
 	var image string
 	var command []string
 	var args []string
@@ -182,15 +475,26 @@ func (r *DagReconciler) buildPod(dag *workflowv1.Dag, task workflowv1.TaskSpec)
 		args = []string{task.Script}
 	case workflowv1.TaskTypeGo:
 		image = "golang:1.20-alpine"
-		// Go code inline execution
-		// TODO: Use ConfigMap to mount code
+		// task.Script is mounted from the ConfigMap built by
+		// buildScriptConfigMap (see the Volumes below) rather than
+		// interpolated into the shell command: a literal go run main.go
+		// string built with task.Script would let a single quote in the
+		// script break out of the quoting, or run arbitrary shell.
 		command = []string{"/bin/sh", "-c"}
-		// Simple inline execution example (complex code should use ConfigMap)
-		goCmd := fmt.Sprintf("echo '%s' > main.go && go run main.go", task.Script)
-		args = []string{goCmd}
+		args = []string{fmt.Sprintf("cp %s/main.go main.go && go mod init dag && go mod tidy && go run main.go", scriptsMountPath)}
+	case workflowv1.TaskTypeCustom, workflowv1.TaskTypeRun, workflowv1.TaskTypeSysBatch:
+		// These types delegate execution to internal/runner/internal/executor
+		// (connector registry, custom executors, per-node fanout) rather than
+		// running as a single Pod built from an image/command/args triple.
+		// DagReconciler doesn't call into that dispatch path yet, so rather
+		// than silently create a broken empty-image Pod, fail the task and
+		// let the caller record why.
+		return nil, fmt.Errorf("task %q: DagReconciler cannot run task type %q directly; it requires the internal/runner dispatch path", task.Name, task.Type)
+	default:
+		return nil, fmt.Errorf("task %q: unknown task type %q", task.Name, task.Type)
 	}
 
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      podName,
 			Namespace: dag.Namespace,
@@ -207,10 +511,91 @@ func (r *DagReconciler) buildPod(dag *workflowv1.Dag, task workflowv1.TaskSpec)
 					Image:   image,
 					Command: command,
 					Args:    args,
+					Env:     buildEnv(task.Env),
 				},
 			},
 		},
 	}
+
+	if r.buildScriptConfigMap(dag, task) != nil {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: scriptsConfigMapVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: scriptConfigMapName(dag, task)},
+				},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      scriptsConfigMapVolumeName,
+			MountPath: scriptsMountPath,
+		})
+	}
+
+	return pod, nil
+}
+
+// failTask marks taskName's TaskStatus (pre-created Pending by getNextTasks)
+// as Failed with err's message, for a task getNextTasks selected to run but
+// that buildPod could not turn into a Pod (see its TaskTypeCustom/
+// TaskTypeRun/TaskTypeSysBatch and default cases).
+func failTask(dag *workflowv1.Dag, taskName string, err error) {
+	for i := range dag.Status.TaskStatuses {
+		if dag.Status.TaskStatuses[i].Name == taskName {
+			dag.Status.TaskStatuses[i].State = workflowv1.StateFailed
+			dag.Status.TaskStatuses[i].PodName = ""
+			dag.Status.TaskStatuses[i].LastError = err.Error()
+			return
+		}
+	}
+}
+
+// scriptsConfigMapVolumeName and scriptsMountPath are shared between the
+// per-task script ConfigMap (see buildScriptConfigMap) and buildPod's
+// TaskTypeGo case, mirroring internal/executor/pod.Executor's equivalents.
+const (
+	scriptsConfigMapVolumeName = "nautikus-scripts"
+	scriptsMountPath           = "/nautikus/scripts"
+)
+
+// scriptConfigMapName names the ConfigMap holding task's inline script
+// content for dag, matching the name buildScriptConfigMap gives it.
+func scriptConfigMapName(dag *workflowv1.Dag, task workflowv1.TaskSpec) string {
+	return fmt.Sprintf("%s-%s-script", dag.Name, task.Name)
+}
+
+// buildScriptConfigMap builds the ConfigMap holding task's inline Go source
+// so buildPod can mount it instead of embedding it in a shell command line,
+// or nil for any task type that doesn't need one. Mirrors
+// internal/executor/pod.Executor.buildScriptConfigMap.
+func (r *DagReconciler) buildScriptConfigMap(dag *workflowv1.Dag, task workflowv1.TaskSpec) *corev1.ConfigMap {
+	if task.Type != workflowv1.TaskTypeGo || task.Script == "" {
+		return nil
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scriptConfigMapName(dag, task),
+			Namespace: dag.Namespace,
+			Labels: map[string]string{
+				"dag":  dag.Name,
+				"task": task.Name,
+			},
+		},
+		Data: map[string]string{"main.go": task.Script},
+	}
+}
+
+// buildEnv converts a TaskSpec.Env map into corev1.EnvVar entries.
+func buildEnv(env map[string]string) []corev1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	vars := make([]corev1.EnvVar, 0, len(env))
+	for k, v := range env {
+		vars = append(vars, corev1.EnvVar{Name: k, Value: v})
+	}
+	return vars
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,104 @@
+// Package eventapi exposes store.EventStore over the gRPC service defined in
+// api/proto/events.proto, so external systems can react to workflow events
+// without polling the Dag status via the Kubernetes API server.
+//
+// pb is generated from api/proto/events.proto via:
+//
+//	protoc --go_out=. --go-grpc_out=. api/proto/events.proto
+package eventapi
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kination/nautikus/internal/eventapi/pb"
+	"github.com/kination/nautikus/internal/store"
+)
+
+// Server implements pb.EventServiceServer on top of a store.EventStore.
+type Server struct {
+	pb.UnimplementedEventServiceServer
+	events store.EventStore
+}
+
+// NewServer creates an eventapi Server backed by the given EventStore.
+func NewServer(events store.EventStore) *Server {
+	return &Server{events: events}
+}
+
+// Subscribe streams events matching req to the client as they are published.
+func (s *Server) Subscribe(req *pb.EventFilter, stream pb.EventService_SubscribeServer) error {
+	ch, err := s.events.Subscribe(stream.Context(), toStoreFilter(req))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toProtoEvent(event)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// GetEvents returns historical events matching req.Filter.
+func (s *Server) GetEvents(ctx context.Context, req *pb.GetEventsRequest) (*pb.GetEventsResponse, error) {
+	events, err := s.events.GetEvents(ctx, toStoreFilter(req.GetFilter()), store.ListOptions{
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	resp := &pb.GetEventsResponse{Events: make([]*pb.Event, 0, len(events))}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toProtoEvent(event))
+	}
+	return resp, nil
+}
+
+func toStoreFilter(f *pb.EventFilter) store.EventFilter {
+	filter := store.EventFilter{
+		DAGNamespace: f.GetDagNamespace(),
+		DAGName:      f.GetDagName(),
+	}
+	for _, t := range f.GetTypes() {
+		filter.Types = append(filter.Types, store.EventType(t))
+	}
+	if since := f.GetSince(); since != nil {
+		t := since.AsTime()
+		filter.Since = &t
+	}
+	if until := f.GetUntil(); until != nil {
+		t := until.AsTime()
+		filter.Until = &t
+	}
+	return filter
+}
+
+func toProtoEvent(event *store.Event) *pb.Event {
+	data := make(map[string]string, len(event.Data))
+	for k, v := range event.Data {
+		data[k] = fmt.Sprintf("%v", v)
+	}
+
+	return &pb.Event{
+		Id:           event.ID,
+		Type:         string(event.Type),
+		Timestamp:    timestamppb.New(event.Timestamp),
+		DagNamespace: event.DAGNamespace,
+		DagName:      event.DAGName,
+		TaskName:     event.TaskName,
+		Data:         data,
+	}
+}
@@ -0,0 +1,147 @@
+// Package custom implements a generic executor for workflowv1.TaskTypeRun
+// tasks: it creates a workflowv1.TaskRun handoff object and waits for an
+// external controller to set its Succeeded condition, Tekton CustomRun
+// style. This lets new execution backends plug in by reconciling TaskRun
+// without Nautikus shipping a connector.ExternalResourceConnector for each
+// one (contrast internal/connector, used by TaskTypeCustom).
+package custom
+
+import (
+	"context"
+	"fmt"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/executor"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// EnableCustomTasksConfigMap is the ConfigMap (in the manager's own
+// namespace) that gates RegisterIfEnabled: its data["enabled"] must be
+// "true" for the custom task Executor to be registered at startup.
+const EnableCustomTasksConfigMap = "enable-custom-tasks"
+
+// Executor runs workflowv1.TaskTypeRun tasks by creating a
+// workflowv1.TaskRun and mapping its Succeeded condition back into a
+// workflowv1.TaskState.
+type Executor struct {
+	executor.BaseExecutor
+}
+
+// New creates a custom task Executor.
+func New(cfg executor.ExecutorConfig) *Executor {
+	return &Executor{BaseExecutor: executor.NewBaseExecutor(cfg)}
+}
+
+func (e *Executor) Type() []workflowv1.TaskType {
+	return []workflowv1.TaskType{workflowv1.TaskTypeRun}
+}
+
+// Execute creates the TaskRun handoff object. The external controller
+// watching task.Ref's GVK is responsible for everything after that.
+func (e *Executor) Execute(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) error {
+	task := executor.TaskSpecFrom(spec, data)
+	if task.Ref == nil {
+		return fmt.Errorf("task %s is TaskTypeRun but has no Ref", task.Name)
+	}
+
+	run := &workflowv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskRunName(dag, &task),
+			Namespace: dag.Namespace,
+			Labels: map[string]string{
+				"dag":  dag.Name,
+				"task": task.Name,
+			},
+		},
+		Spec: workflowv1.TaskRunSpec{
+			Ref:    *task.Ref,
+			Params: task.Ref.Params,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(dag, run, e.Config.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on TaskRun %s: %w", run.Name, err)
+	}
+
+	return e.Config.Client.Create(ctx, run)
+}
+
+// GetStatus reads the TaskRun's Succeeded condition.
+func (e *Executor) GetStatus(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) (workflowv1.TaskState, error) {
+	task := executor.TaskSpecFrom(spec, data)
+	run := &workflowv1.TaskRun{}
+	err := e.Config.Client.Get(ctx, types.NamespacedName{Name: taskRunName(dag, &task), Namespace: dag.Namespace}, run)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return workflowv1.StatePending, nil
+		}
+		return workflowv1.StateFailed, err
+	}
+
+	for _, cond := range run.Status.Conditions {
+		if cond.Type != "Succeeded" {
+			continue
+		}
+		switch cond.Status {
+		case metav1.ConditionTrue:
+			return workflowv1.StateCompleted, nil
+		case metav1.ConditionFalse:
+			return workflowv1.StateFailed, nil
+		default:
+			return workflowv1.StateRunning, nil
+		}
+	}
+	return workflowv1.StateRunning, nil
+}
+
+// Cleanup deletes the TaskRun (OwnerReference would eventually do this too).
+func (e *Executor) Cleanup(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) error {
+	task := executor.TaskSpecFrom(spec, data)
+	run := &workflowv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskRunName(dag, &task),
+			Namespace: dag.Namespace,
+		},
+	}
+	if err := e.Config.Client.Delete(ctx, run); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Instances always returns (nil, nil): the custom executor delegates to an
+// external controller per TaskRun and has no notion of per-node fanout.
+func (e *Executor) Instances(ctx context.Context) ([]executor.NodeID, error) {
+	return nil, nil
+}
+
+func taskRunName(dag *workflowv1.Dag, task *workflowv1.TaskSpec) string {
+	return fmt.Sprintf("%s-%s", dag.Name, task.Name)
+}
+
+// RegisterIfEnabled reads the enable-custom-tasks ConfigMap from namespace
+// and, only if present with data["enabled"] == "true", registers a custom
+// Executor into registry. A missing ConfigMap is treated as disabled rather
+// than a startup failure, so clusters that don't need custom tasks don't
+// need to create one.
+func RegisterIfEnabled(ctx context.Context, cl client.Client, registry *executor.Registry, namespace string, cfg executor.ExecutorConfig) error {
+	var cm corev1.ConfigMap
+	err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: EnableCustomTasksConfigMap}, &cm)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s ConfigMap: %w", EnableCustomTasksConfigMap, err)
+	}
+	if cm.Data["enabled"] != "true" {
+		return nil
+	}
+
+	registry.Register(New(cfg))
+	return nil
+}
@@ -5,8 +5,9 @@ package executor
 import (
 	"context"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -16,20 +17,58 @@ type Executor interface {
 	// Type returns the task type(s) this executor handles
 	Type() []workflowv1.TaskType
 
-	// Execute creates the resources needed to run the task
-	Execute(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error
+	// Execute creates the resources needed to run the task. spec is the
+	// task's compact, persistable identity; data carries the bulky fields
+	// (image/command/script/env/placement) an executor needs to actually
+	// run it - see ExecutorTaskSpec/ExecutorTaskSpecData and Rehydrate.
+	Execute(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) error
 
 	// GetStatus retrieves the current status of the task
-	GetStatus(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (workflowv1.TaskState, error)
+	GetStatus(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) (workflowv1.TaskState, error)
 
 	// Cleanup removes the resources created for the task (optional, OwnerReference handles most cases)
-	Cleanup(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error
+	Cleanup(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) error
+
+	// Instances lists the nodes/workers this executor can currently run a
+	// workflowv1.TaskTypeSysBatch task's per-node fanout on (see
+	// internal/scheduler's sysBatch helpers). Executors with no notion of
+	// per-node fanout may return (nil, nil).
+	Instances(ctx context.Context) ([]NodeID, error)
+}
+
+// NodeID identifies one node/worker a TaskTypeSysBatch task can be fanned
+// out onto, as reported by Executor.Instances.
+type NodeID string
+
+// OutputCapturer is implemented by executors that can recover a completed
+// task's outputs (e.g. by tailing its logs for a trailing JSON object), for
+// workflowv1.BranchRule evaluation. Not every executor can do this, so the
+// Runner type-asserts for it after a task completes and simply skips output
+// capture when an executor doesn't implement it.
+type OutputCapturer interface {
+	// Outputs returns the key/value pairs a completed task produced, or an
+	// empty map if it produced none.
+	Outputs(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (map[string]string, error)
+}
+
+// ResultsCapturer is implemented by executors that can recover the result
+// files a completed task wrote for $(tasks.<name>.result.<key>) substitution
+// (see internal/substitution). Like OutputCapturer, the Runner type-asserts
+// for it and skips capture when an executor doesn't implement it.
+type ResultsCapturer interface {
+	// Results returns the key/value pairs a completed task wrote to its
+	// results directory, or an empty map if it wrote none.
+	Results(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (map[string]string, error)
 }
 
 // ExecutorConfig holds common configuration for executors
 type ExecutorConfig struct {
 	Client client.Client
 	Scheme *runtime.Scheme
+	// Clientset is used by executors that need APIs controller-runtime's
+	// client.Client doesn't cover, e.g. reading Pod logs for OutputCapturer.
+	// Optional: nil unless an executor needs it.
+	Clientset kubernetes.Interface
 }
 
 // BaseExecutor provides common functionality for executors
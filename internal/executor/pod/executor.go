@@ -3,15 +3,19 @@ package pod
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 	"github.com/kination/nautikus/internal/executor"
 )
 
@@ -33,12 +37,51 @@ func (e *Executor) Type() []workflowv1.TaskType {
 		workflowv1.TaskTypeBash,
 		workflowv1.TaskTypePython,
 		workflowv1.TaskTypeGo,
+		workflowv1.TaskTypeSysBatch,
 	}
 }
 
-// Execute creates a Pod to run the task
-func (e *Executor) Execute(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error {
-	pod := e.buildPod(dag, task)
+// Instances lists every schedulable cluster Node by name, the set
+// TaskTypeSysBatch fanout draws from (see internal/scheduler's sysBatch
+// helpers). TaskSpec.NodeSelector then pins each per-node clone's Pod back
+// onto its specific node via Spec.NodeSelector in buildPod, since Instances
+// itself has no task to filter by.
+func (e *Executor) Instances(ctx context.Context) ([]executor.NodeID, error) {
+	var nodes corev1.NodeList
+	if err := e.Config.Client.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+
+	ids := make([]executor.NodeID, 0, len(nodes.Items))
+	for _, n := range nodes.Items {
+		if n.Spec.Unschedulable {
+			continue
+		}
+		ids = append(ids, executor.NodeID(n.Name))
+	}
+	return ids, nil
+}
+
+// Execute creates a Pod to run the task, first creating the ConfigMap that
+// holds its script when the task has inline Command/Script content (see
+// buildScriptConfigMap).
+func (e *Executor) Execute(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) error {
+	task := executor.TaskSpecFrom(spec, data)
+	if cm := e.buildScriptConfigMap(dag, &task); cm != nil {
+		if err := controllerutil.SetControllerReference(dag, cm, e.Config.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference on ConfigMap %s: %w", cm.Name, err)
+		}
+		if err := e.Config.Client.Create(ctx, cm); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create script ConfigMap %s: %w", cm.Name, err)
+		}
+	}
+
+	latest, err := e.latestAttempt(ctx, dag, &task)
+	if err != nil {
+		return fmt.Errorf("failed to look up previous attempts for %s: %w", task.Name, err)
+	}
+
+	pod := e.buildPod(dag, &task, latest+1)
 
 	// Set owner reference (Pod will be deleted when DAG is deleted)
 	if err := controllerutil.SetControllerReference(dag, pod, e.Config.Scheme); err != nil {
@@ -56,11 +99,19 @@ func (e *Executor) Execute(ctx context.Context, dag *workflowv1.Dag, task *workf
 }
 
 // GetStatus retrieves the current status of the task by checking the Pod status
-func (e *Executor) GetStatus(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (workflowv1.TaskState, error) {
-	podName := e.getPodName(dag.Name, task.Name)
+func (e *Executor) GetStatus(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) (workflowv1.TaskState, error) {
+	task := executor.TaskSpecFrom(spec, data)
+	latest, err := e.latestAttempt(ctx, dag, &task)
+	if err != nil {
+		return workflowv1.StateFailed, err
+	}
+	if latest < 0 {
+		return workflowv1.StatePending, nil
+	}
+	podName := e.getPodName(dag.Name, task.Name, latest)
 
 	pod := &corev1.Pod{}
-	err := e.Config.Client.Get(ctx, types.NamespacedName{
+	err = e.Config.Client.Get(ctx, types.NamespacedName{
 		Name:      podName,
 		Namespace: dag.Namespace,
 	}, pod)
@@ -84,9 +135,19 @@ func (e *Executor) GetStatus(ctx context.Context, dag *workflowv1.Dag, task *wor
 	}
 }
 
-// Cleanup removes the Pod (usually handled by OwnerReference)
-func (e *Executor) Cleanup(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error {
-	podName := e.getPodName(dag.Name, task.Name)
+// Cleanup removes the most recent attempt's Pod (usually handled by
+// OwnerReference); older attempts' Pods are left for GC to reclaim, so a
+// retry doesn't erase the log history of the attempt that prompted it.
+func (e *Executor) Cleanup(ctx context.Context, dag *workflowv1.Dag, spec executor.ExecutorTaskSpec, data executor.ExecutorTaskSpecData) error {
+	task := executor.TaskSpecFrom(spec, data)
+	latest, err := e.latestAttempt(ctx, dag, &task)
+	if err != nil {
+		return err
+	}
+	if latest < 0 {
+		return nil
+	}
+	podName := e.getPodName(dag.Name, task.Name, latest)
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -104,45 +165,322 @@ func (e *Executor) Cleanup(ctx context.Context, dag *workflowv1.Dag, task *workf
 	return nil
 }
 
-// buildPod converts TaskSpec to Pod
-func (e *Executor) buildPod(dag *workflowv1.Dag, task *workflowv1.TaskSpec) *corev1.Pod {
-	podName := e.getPodName(dag.Name, task.Name)
+// Outputs implements executor.OutputCapturer by tailing the task-runner
+// container's log and parsing its last line as a JSON object of string
+// values. Tasks that want to feed a BranchRule print exactly one JSON
+// object as the final line of stdout; anything else is treated as having
+// produced no outputs rather than as an error.
+func (e *Executor) Outputs(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (map[string]string, error) {
+	if e.Config.Clientset == nil {
+		return nil, nil
+	}
+
+	latest, err := e.latestAttempt(ctx, dag, task)
+	if err != nil {
+		return nil, err
+	}
+	if latest < 0 {
+		return nil, nil
+	}
+	podName := e.getPodName(dag.Name, task.Name, latest)
+	raw, err := e.Config.Clientset.CoreV1().Pods(dag.Namespace).
+		GetLogs(podName, &corev1.PodLogOptions{Container: "task-runner"}).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs for %s: %w", podName, err)
+	}
+
+	// The entrypoint prints its results marker line after the task's own
+	// output, so drop it before looking for the task's last line.
+	logs := string(raw)
+	if i := strings.LastIndex(logs, resultsMarkerPrefix); i >= 0 {
+		logs = logs[:i]
+	}
+	lastLine := lastNonEmptyLine(logs)
+	if lastLine == "" {
+		return nil, nil
+	}
+
+	var outputs map[string]string
+	if err := json.Unmarshal([]byte(lastLine), &outputs); err != nil {
+		// The task didn't print a JSON output line; that's not an error.
+		return nil, nil
+	}
+	return outputs, nil
+}
+
+func lastNonEmptyLine(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// Results implements executor.ResultsCapturer by tailing the task-runner
+// container's log for the entrypoint's resultsMarkerPrefix line, which
+// carries whatever the task wrote under its results directory.
+func (e *Executor) Results(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (map[string]string, error) {
+	if e.Config.Clientset == nil {
+		return nil, nil
+	}
+
+	latest, err := e.latestAttempt(ctx, dag, task)
+	if err != nil {
+		return nil, err
+	}
+	if latest < 0 {
+		return nil, nil
+	}
+	podName := e.getPodName(dag.Name, task.Name, latest)
+	raw, err := e.Config.Clientset.CoreV1().Pods(dag.Namespace).
+		GetLogs(podName, &corev1.PodLogOptions{Container: "task-runner"}).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs for %s: %w", podName, err)
+	}
+
+	line := findPrefixedLine(string(raw), resultsMarkerPrefix)
+	if line == "" {
+		return nil, nil
+	}
+
+	var results map[string]string
+	if err := json.Unmarshal([]byte(line), &results); err != nil {
+		return nil, nil
+	}
+	return results, nil
+}
+
+// resultsMarkerPrefix matches cmd/entrypoint's own constant of the same
+// name; it tags the results JSON line in stdout so it can't be confused
+// with a task's own BranchRule output line.
+const resultsMarkerPrefix = "__NAUTIKUS_RESULTS__"
+
+// findPrefixedLine returns the content following prefix on the last line of
+// s that starts with it, or "" if no line does.
+func findPrefixedLine(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimPrefix(line, prefix)
+		}
+	}
+	return ""
+}
+
+// scriptVolumeName and scriptMountPath are shared between the fetch-script
+// init container and the task-runner container for ScriptRef tasks.
+const (
+	scriptVolumeName = "nautikus-script"
+	scriptMountPath  = "/nautikus"
+	scriptFilePath   = scriptMountPath + "/script"
+)
+
+// scriptsConfigMapVolumeName and scriptsMountPath are shared between the
+// per-task script ConfigMap (see buildScriptConfigMap) and the task-runner
+// container for tasks with inline Command/Script content. Mounting the
+// script as a file instead of shell-quoting it into the container's command
+// (the old `echo '...' > main.go` approach) avoids breaking on scripts that
+// contain single quotes.
+const (
+	scriptsConfigMapVolumeName = "nautikus-scripts"
+	scriptsMountPath           = "/nautikus/scripts"
+)
+
+// entrypointVolumeName, entrypointMountPath and entrypointBinPath are shared
+// between the install-entrypoint init container and the task-runner
+// container: the init container copies its own binary onto a volume so the
+// task-runner container can use it as its actual Command, Tekton
+// entrypoint-rewriting style.
+const (
+	entrypointVolumeName = "nautikus-entrypoint"
+	entrypointMountPath  = "/nautikus/bin"
+	entrypointBinPath    = entrypointMountPath + "/entrypoint"
+	postFilePath         = "/nautikus/post/done"
+)
+
+// buildPod converts TaskSpec to Pod. attempt is this Pod's 0-based attempt
+// index (see getPodName).
+func (e *Executor) buildPod(dag *workflowv1.Dag, task *workflowv1.TaskSpec, attempt int) *corev1.Pod {
+	podName := e.getPodName(dag.Name, task.Name, attempt)
+
+	image, realCommand, realArgs := e.getContainerSpec(task)
+
+	entrypointArgs := append([]string{fmt.Sprintf("--post-file=%s", postFilePath), "--"}, realCommand...)
+	entrypointArgs = append(entrypointArgs, realArgs...)
+
+	spec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		NodeSelector:  task.NodeSelector,
+		Containers: []corev1.Container{
+			{
+				Name:    "task-runner",
+				Image:   image,
+				Command: []string{entrypointBinPath},
+				Args:    entrypointArgs,
+				Env:     e.buildEnv(task.Env),
+			},
+		},
+	}
+
+	e.addEntrypoint(&spec)
 
-	image, command, args := e.getContainerSpec(task)
+	if task.ScriptRef != nil {
+		e.addScriptFetch(&spec, task.ScriptRef)
+	} else if key, _ := scriptConfigMapEntry(task); key != "" {
+		e.addScriptConfigMap(&spec, fmt.Sprintf("%s-%s-script", dag.Name, task.Name))
+	}
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      podName,
 			Namespace: dag.Namespace,
 			Labels: map[string]string{
-				"dag":                      dag.Name,
-				"task":                     task.Name,
-				"app.kubernetes.io/name":  "nautikus",
+				"dag":                       dag.Name,
+				"task":                      task.Name,
+				attemptLabel:                strconv.Itoa(attempt),
+				"app.kubernetes.io/name":    "nautikus",
 				"app.kubernetes.io/part-of": "nautikus",
 			},
 		},
-		Spec: corev1.PodSpec{
-			RestartPolicy: corev1.RestartPolicyNever,
-			Containers: []corev1.Container{
-				{
-					Name:    "task-runner",
-					Image:   image,
-					Command: command,
-					Args:    args,
-					Env:     e.buildEnv(task.Env),
-				},
+		Spec: spec,
+	}
+}
+
+// addEntrypoint adds the install-entrypoint init container and its shared
+// emptyDir volume, and mounts it into every container in spec.
+func (e *Executor) addEntrypoint(spec *corev1.PodSpec) {
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name:         entrypointVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:    "install-entrypoint",
+		Image:   "nautikus/entrypoint:latest",
+		Command: []string{"cp", "/entrypoint", entrypointBinPath},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: entrypointVolumeName, MountPath: entrypointMountPath},
+		},
+	})
+
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      entrypointVolumeName,
+			MountPath: entrypointMountPath,
+		})
+	}
+}
+
+// addScriptFetch adds an init container that resolves ref against its
+// scriptstore backend and writes the content to a volume shared with the
+// task-runner container, so the Pod spec itself never carries the script.
+func (e *Executor) addScriptFetch(spec *corev1.PodSpec, ref *workflowv1.ScriptRef) {
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name:         scriptVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:  "fetch-script",
+		Image: "nautikus/script-fetch:latest",
+		Args: []string{
+			"-store-ref", ref.StoreRef,
+			"-digest", ref.Digest,
+			"-out", scriptFilePath,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: scriptVolumeName, MountPath: scriptMountPath},
+		},
+	})
+
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      scriptVolumeName,
+			MountPath: scriptMountPath,
+		})
+	}
+}
+
+// addScriptConfigMap mounts the per-task script ConfigMap named
+// configMapName at scriptsMountPath in every container in spec.
+func (e *Executor) addScriptConfigMap(spec *corev1.PodSpec, configMapName string) {
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: scriptsConfigMapVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
 			},
 		},
+	})
+
+	for i := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      scriptsConfigMapVolumeName,
+			MountPath: scriptsMountPath,
+		})
 	}
 }
 
-// getContainerSpec returns image, command, and args based on task type
+// buildScriptConfigMap builds the ConfigMap holding task's inline
+// Command/Script content, or nil when the task has none (e.g. it uses a
+// ScriptRef instead, or is a task type this executor doesn't run).
+func (e *Executor) buildScriptConfigMap(dag *workflowv1.Dag, task *workflowv1.TaskSpec) *corev1.ConfigMap {
+	if task.ScriptRef != nil {
+		return nil
+	}
+	key, content := scriptConfigMapEntry(task)
+	if key == "" {
+		return nil
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-script", dag.Name, task.Name),
+			Namespace: dag.Namespace,
+			Labels: map[string]string{
+				"dag":  dag.Name,
+				"task": task.Name,
+			},
+		},
+		Data: map[string]string{key: content},
+	}
+}
+
+// scriptConfigMapEntry returns the ConfigMap key and content task's inline
+// Command/Script should be mounted under, or ("", "") if it has none.
+func scriptConfigMapEntry(task *workflowv1.TaskSpec) (key, content string) {
+	switch task.Type {
+	case workflowv1.TaskTypeBash:
+		if task.Command == "" {
+			return "", ""
+		}
+		return "entrypoint.sh", task.Command
+	case workflowv1.TaskTypePython:
+		if task.Script == "" {
+			return "", ""
+		}
+		return "script.py", task.Script
+	case workflowv1.TaskTypeGo:
+		if task.Script == "" {
+			return "", ""
+		}
+		return "main.go", task.Script
+	default:
+		return "", ""
+	}
+}
+
+// getContainerSpec returns the image and the real (non-entrypoint-wrapped)
+// command and args to run for task's type, reading its script from a
+// ScriptRef-fetched file or its mounted ConfigMap rather than embedding it
+// into the command line.
 func (e *Executor) getContainerSpec(task *workflowv1.TaskSpec) (string, []string, []string) {
 	var image string
-	var command []string
-	var args []string
-
-	// Use custom image if specified
 	if task.Image != "" {
 		image = task.Image
 	}
@@ -152,26 +490,33 @@ func (e *Executor) getContainerSpec(task *workflowv1.TaskSpec) (string, []string
 		if image == "" {
 			image = "ubuntu:latest"
 		}
-		command = []string{"/bin/bash", "-c"}
-		args = []string{task.Command}
+		if task.ScriptRef != nil {
+			return image, []string{"bash"}, []string{scriptFilePath}
+		}
+		return image, []string{"bash"}, []string{scriptsMountPath + "/entrypoint.sh"}
 
 	case workflowv1.TaskTypePython:
 		if image == "" {
 			image = "python:3.9-slim"
 		}
-		command = []string{"python", "-c"}
-		args = []string{task.Script}
+		if task.ScriptRef != nil {
+			return image, []string{"python"}, []string{scriptFilePath}
+		}
+		return image, []string{"python"}, []string{scriptsMountPath + "/script.py"}
 
 	case workflowv1.TaskTypeGo:
 		if image == "" {
 			image = "golang:1.20-alpine"
 		}
-		command = []string{"/bin/sh", "-c"}
-		goCmd := fmt.Sprintf("echo '%s' > main.go && go mod init dag && go mod tidy && go run main.go", task.Script)
-		args = []string{goCmd}
+		src := scriptFilePath
+		if task.ScriptRef == nil {
+			src = scriptsMountPath + "/main.go"
+		}
+		goCmd := fmt.Sprintf("cp %s main.go && go mod init dag && go mod tidy && go run main.go", src)
+		return image, []string{"/bin/sh", "-c"}, []string{goCmd}
 	}
 
-	return image, command, args
+	return image, nil, nil
 }
 
 // buildEnv converts map to EnvVar slice
@@ -186,7 +531,38 @@ func (e *Executor) buildEnv(envMap map[string]string) []corev1.EnvVar {
 	return envVars
 }
 
-// getPodName generates the pod name from dag and task names
-func (e *Executor) getPodName(dagName, taskName string) string {
-	return fmt.Sprintf("%s-%s", dagName, taskName)
+// attemptLabel records a Pod's 0-based attempt index, so latestAttempt can
+// find the most recently created attempt for a task without needing the
+// caller to track it itself.
+const attemptLabel = "nautikus.io/attempt"
+
+// getPodName names attempt's Pod "<dag>-<task>-<attempt>", so a retried
+// task's earlier attempts keep their own Pods around (until GC) instead of
+// being overwritten under the same name.
+func (e *Executor) getPodName(dagName, taskName string, attempt int) string {
+	return fmt.Sprintf("%s-%s-%d", dagName, taskName, attempt)
+}
+
+// latestAttempt returns the highest attempt index among Pods already
+// created for task, or -1 if none exist yet.
+func (e *Executor) latestAttempt(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (int, error) {
+	var pods corev1.PodList
+	if err := e.Config.Client.List(ctx, &pods,
+		client.InNamespace(dag.Namespace),
+		client.MatchingLabels{"dag": dag.Name, "task": task.Name},
+	); err != nil {
+		return -1, fmt.Errorf("failed to list attempt Pods for %s: %w", task.Name, err)
+	}
+
+	latest := -1
+	for _, p := range pods.Items {
+		n, err := strconv.Atoi(p.Labels[attemptLabel])
+		if err != nil {
+			continue
+		}
+		if n > latest {
+			latest = n
+		}
+	}
+	return latest, nil
 }
@@ -10,7 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 	"github.com/kination/nautikus/internal/executor"
 )
 
@@ -88,10 +88,10 @@ func TestExecutor_BuildPod_Bash(t *testing.T) {
 		Command: "echo hello",
 	}
 
-	pod := exec.buildPod(dag, task)
+	pod := exec.buildPod(dag, task, 0)
 
 	// Verify pod name
-	expectedName := "test-dag-test-task"
+	expectedName := "test-dag-test-task-0"
 	if pod.Name != expectedName {
 		t.Errorf("expected pod name %s, got %s", expectedName, pod.Name)
 	}
@@ -101,15 +101,36 @@ func TestExecutor_BuildPod_Bash(t *testing.T) {
 		t.Errorf("expected ubuntu:latest image for Bash, got %s", pod.Spec.Containers[0].Image)
 	}
 
-	// Verify command
-	if pod.Spec.Containers[0].Command[0] != "/bin/bash" {
-		t.Errorf("expected /bin/bash command, got %v", pod.Spec.Containers[0].Command)
+	// The container's actual Command is the injected entrypoint binary; the
+	// real command is passed through as args after "--".
+	if pod.Spec.Containers[0].Command[0] != entrypointBinPath {
+		t.Errorf("expected %s command, got %v", entrypointBinPath, pod.Spec.Containers[0].Command)
+	}
+	if !containsArg(pod.Spec.Containers[0].Args, "bash") {
+		t.Errorf("expected bash in entrypoint args, got %v", pod.Spec.Containers[0].Args)
+	}
+	if !containsArg(pod.Spec.Containers[0].Args, scriptsMountPath+"/entrypoint.sh") {
+		t.Errorf("expected mounted script path in entrypoint args, got %v", pod.Spec.Containers[0].Args)
 	}
 
-	// Verify args contain the command
-	if pod.Spec.Containers[0].Args[0] != "echo hello" {
-		t.Errorf("expected 'echo hello' in args, got %v", pod.Spec.Containers[0].Args)
+	// The script itself is mounted from a ConfigMap, not shell-quoted into
+	// the command line.
+	cm := exec.buildScriptConfigMap(dag, task)
+	if cm == nil {
+		t.Fatal("expected a script ConfigMap to be built")
+	}
+	if cm.Data["entrypoint.sh"] != "echo hello" {
+		t.Errorf("expected ConfigMap to carry the command, got %q", cm.Data["entrypoint.sh"])
+	}
+}
+
+func containsArg(args []string, want string) bool {
+	for _, a := range args {
+		if a == want {
+			return true
+		}
 	}
+	return false
 }
 
 func TestExecutor_BuildPod_Python(t *testing.T) {
@@ -134,16 +155,16 @@ func TestExecutor_BuildPod_Python(t *testing.T) {
 		Script: "print('hello')",
 	}
 
-	pod := exec.buildPod(dag, task)
+	pod := exec.buildPod(dag, task, 0)
 
 	// Verify image
 	if pod.Spec.Containers[0].Image != "python:3.9-slim" {
 		t.Errorf("expected python:3.9-slim image, got %s", pod.Spec.Containers[0].Image)
 	}
 
-	// Verify command
-	if pod.Spec.Containers[0].Command[0] != "python" {
-		t.Errorf("expected python command, got %v", pod.Spec.Containers[0].Command)
+	// Verify the real command (after the entrypoint's "--") is python
+	if !containsArg(pod.Spec.Containers[0].Args, "python") {
+		t.Errorf("expected python in entrypoint args, got %v", pod.Spec.Containers[0].Args)
 	}
 }
 
@@ -169,7 +190,7 @@ func TestExecutor_BuildPod_Go(t *testing.T) {
 		Script: "package main\nfunc main() {}",
 	}
 
-	pod := exec.buildPod(dag, task)
+	pod := exec.buildPod(dag, task, 0)
 
 	// Verify image
 	if pod.Spec.Containers[0].Image != "golang:1.20-alpine" {
@@ -202,7 +223,7 @@ func TestExecutor_BuildPod_WithEnv(t *testing.T) {
 		},
 	}
 
-	pod := exec.buildPod(dag, task)
+	pod := exec.buildPod(dag, task, 0)
 
 	// Verify env var is set
 	found := false
@@ -251,8 +272,9 @@ func TestExecutor_GetStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			pod := &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-dag-test-task",
+					Name:      "test-dag-test-task-0",
 					Namespace: "default",
+					Labels:    map[string]string{"dag": "test-dag", "task": "test-task", attemptLabel: "0"},
 				},
 				Status: corev1.PodStatus{
 					Phase: tt.podPhase,
@@ -327,8 +349,9 @@ func TestExecutor_Cleanup(t *testing.T) {
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-dag-test-task",
+			Name:      "test-dag-test-task-0",
 			Namespace: "default",
+			Labels:    map[string]string{"dag": "test-dag", "task": "test-task", attemptLabel: "0"},
 		},
 	}
 
@@ -358,7 +381,7 @@ func TestExecutor_Cleanup(t *testing.T) {
 
 	// Verify pod is deleted
 	deletedPod := &corev1.Pod{}
-	err = client.Get(context.Background(), types.NamespacedName{Name: "test-dag-test-task", Namespace: "default"}, deletedPod)
+	err = client.Get(context.Background(), types.NamespacedName{Name: "test-dag-test-task-0", Namespace: "default"}, deletedPod)
 	if err == nil {
 		t.Error("pod should be deleted")
 	}
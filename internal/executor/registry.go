@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"sync"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 )
 
 // Registry manages executor registration and lookup
@@ -4,7 +4,7 @@ import (
 	"context"
 	"testing"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 )
 
 // MockExecutor is a mock implementation for testing
@@ -16,18 +16,22 @@ func (m *MockExecutor) Type() []workflowv1.TaskType {
 	return m.taskTypes
 }
 
-func (m *MockExecutor) Execute(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error {
+func (m *MockExecutor) Execute(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) error {
 	return nil
 }
 
-func (m *MockExecutor) GetStatus(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (workflowv1.TaskState, error) {
+func (m *MockExecutor) GetStatus(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) (workflowv1.TaskState, error) {
 	return workflowv1.StateCompleted, nil
 }
 
-func (m *MockExecutor) Cleanup(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) error {
+func (m *MockExecutor) Cleanup(ctx context.Context, dag *workflowv1.Dag, spec ExecutorTaskSpec, data ExecutorTaskSpecData) error {
 	return nil
 }
 
+func (m *MockExecutor) Instances(ctx context.Context) ([]NodeID, error) {
+	return nil, nil
+}
+
 func TestNewRegistry(t *testing.T) {
 	registry := NewRegistry()
 	if registry == nil {
@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// ExecutorTaskSpec is the compact, immutable identity of one task's
+// execution: enough to look the task back up, but none of the container
+// payload an executor actually needs to run it. It is intentionally small
+// enough to persist (e.g. alongside workflowv1.TaskStatus) without
+// duplicating data that's already in the Dag's own Spec.Tasks.
+type ExecutorTaskSpec struct {
+	// ID uniquely identifies this execution within its Dag, e.g. the task's
+	// Name, or "<name>--<nodeID>" for a TaskTypeSysBatch fanout instance
+	// (see internal/scheduler's sysBatch helpers).
+	ID string
+	// TaskName is the task's Name within DagRef's Spec.Tasks/Finally.
+	TaskName string
+	// DagRef is the name of the Dag this task belongs to.
+	DagRef string
+	// NodeID is set for a TaskTypeSysBatch fanout instance pinned to one
+	// node (see ExecutorTaskSpecData.NodeSelector); empty otherwise.
+	NodeID NodeID
+}
+
+// ExecutorTaskSpecData holds the bulky, regenerable-from-the-Dag fields an
+// executor needs to actually run a task: container image/command/script,
+// environment, and placement. It is deliberately kept out of
+// ExecutorTaskSpec (and so out of anything that persists ExecutorTaskSpec)
+// since Rehydrate can always recompute it from the Dag.
+type ExecutorTaskSpecData struct {
+	Type         workflowv1.TaskType
+	Command      string
+	Script       string
+	Image        string
+	ScriptRef    *workflowv1.ScriptRef
+	Ref          *workflowv1.TaskRef
+	CustomRef    *workflowv1.CustomTaskRef
+	Env          map[string]string
+	Timeout      *metav1.Duration
+	NodeSelector map[string]string
+}
+
+// ExecutorTaskStatus is the mutable status an executor reports back for an
+// ExecutorTaskSpec, mirroring the subset of workflowv1.TaskStatus an
+// executor is responsible for populating.
+type ExecutorTaskStatus struct {
+	State     workflowv1.TaskState
+	PodName   string
+	Message   string
+	LastError string
+}
+
+// Rehydrate recomputes taskName's ExecutorTaskSpecData from dag's own
+// Spec.Tasks/Finally, so callers holding only a persisted ExecutorTaskSpec
+// (e.g. after a process restart) can rebuild what an executor needs without
+// that data ever having been persisted separately. Returns an error if
+// taskName isn't found in dag.
+func Rehydrate(dag *workflowv1.Dag, taskName string) (ExecutorTaskSpecData, error) {
+	task, ok := taskSpecByName(dag, taskName)
+	if !ok {
+		return ExecutorTaskSpecData{}, fmt.Errorf("task %s not found in dag %s", taskName, dag.Name)
+	}
+
+	return ExecutorTaskSpecData{
+		Type:         task.Type,
+		Command:      task.Command,
+		Script:       task.Script,
+		Image:        task.Image,
+		ScriptRef:    task.ScriptRef,
+		Ref:          task.Ref,
+		CustomRef:    task.CustomRef,
+		Env:          task.Env,
+		Timeout:      task.Timeout,
+		NodeSelector: task.NodeSelector,
+	}, nil
+}
+
+// SpecOf builds the ExecutorTaskSpec identifying task within dagName, for
+// callers (e.g. Runner) that hold a full *workflowv1.TaskSpec and need to
+// cross the Executor interface boundary with the split types instead.
+// NodeID is left empty; callers fanning a TaskTypeSysBatch task out across
+// nodes set it themselves once a node is chosen.
+func SpecOf(dagName string, task *workflowv1.TaskSpec) ExecutorTaskSpec {
+	return ExecutorTaskSpec{
+		ID:       task.Name,
+		TaskName: task.Name,
+		DagRef:   dagName,
+	}
+}
+
+// DataOf extracts task's ExecutorTaskSpecData, the counterpart to SpecOf
+// for callers that already hold a full *workflowv1.TaskSpec and so have no
+// need for Rehydrate.
+func DataOf(task *workflowv1.TaskSpec) ExecutorTaskSpecData {
+	return ExecutorTaskSpecData{
+		Type:         task.Type,
+		Command:      task.Command,
+		Script:       task.Script,
+		Image:        task.Image,
+		ScriptRef:    task.ScriptRef,
+		Ref:          task.Ref,
+		CustomRef:    task.CustomRef,
+		Env:          task.Env,
+		Timeout:      task.Timeout,
+		NodeSelector: task.NodeSelector,
+	}
+}
+
+// TaskSpecFrom reassembles a workflowv1.TaskSpec from spec/data, the
+// inverse of SpecOf/DataOf. Executors use it to rebuild the TaskSpec shape
+// their existing helpers expect without rewriting those helpers around the
+// split types.
+func TaskSpecFrom(spec ExecutorTaskSpec, data ExecutorTaskSpecData) workflowv1.TaskSpec {
+	return workflowv1.TaskSpec{
+		Name:         spec.TaskName,
+		Type:         data.Type,
+		Command:      data.Command,
+		Script:       data.Script,
+		Image:        data.Image,
+		ScriptRef:    data.ScriptRef,
+		Ref:          data.Ref,
+		CustomRef:    data.CustomRef,
+		Env:          data.Env,
+		Timeout:      data.Timeout,
+		NodeSelector: data.NodeSelector,
+	}
+}
+
+// taskSpecByName looks up name among dag.Spec.Tasks and dag.Spec.Finally,
+// mirroring internal/scheduler's helper of the same name and purpose.
+func taskSpecByName(dag *workflowv1.Dag, name string) (workflowv1.TaskSpec, bool) {
+	for _, t := range dag.Spec.Tasks {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	for _, t := range dag.Spec.Finally {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return workflowv1.TaskSpec{}, false
+}
@@ -0,0 +1,159 @@
+// Package history provides a pluggable store for DAG execution history:
+// one Execution record per DagRun-equivalent submission and one TaskAttempt
+// record per task attempt within it, independent of the live Dag/DagRun CRs
+// the scheduler and controller operate on. This mirrors internal/store's
+// Factory/Register pattern so backends stay swappable without this package
+// importing its own implementations.
+package history
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// Execution records one DAG execution from start to (optionally) finish.
+type Execution struct {
+	// ID uniquely identifies this execution, e.g. the originating DagRun's
+	// name.
+	ID string
+	// DAGName is the name of the Dag this execution ran.
+	DAGName string
+	// Status is the execution's current or final state.
+	Status workflowv1.TaskState
+	// StartTime is when the execution was recorded as started.
+	StartTime time.Time
+	// EndTime is set once the execution reaches a terminal Status.
+	EndTime *time.Time
+	// Total, Failed, Succeeded, InProgress and Stopped mirror
+	// workflowv1.DagRunStatus's counters at the time of the last
+	// UpdateExecutionCounters call.
+	Total      int32
+	Failed     int32
+	Succeeded  int32
+	InProgress int32
+	Stopped    int32
+	// Trigger records what caused this execution, e.g. TriggerScheduled for
+	// a CronTrigger/IntervalTrigger fire.
+	Trigger workflowv1.TriggerType
+}
+
+// TaskAttempt records one attempt at running a single task within an
+// Execution. A task retried via TaskSpec.RetryPolicy produces one
+// TaskAttempt per attempt, distinguished by Attempt.
+type TaskAttempt struct {
+	// ID uniquely identifies this attempt, e.g. "<executionID>/<taskName>/<attempt>".
+	ID string
+	// ExecutionID is the Execution this attempt belongs to.
+	ExecutionID string
+	// TaskName is the task's name within its Dag.
+	TaskName string
+	// ExecutorType identifies which workflowv1.TaskType executed the task,
+	// e.g. TaskTypeBash or TaskTypeCustom.
+	ExecutorType string
+	// Status is this attempt's current or final state.
+	Status workflowv1.TaskState
+	// StartTime is when this attempt started.
+	StartTime time.Time
+	// EndTime is set once this attempt reaches a terminal Status.
+	EndTime *time.Time
+	// Error holds the attempt's failure message, if any.
+	Error string
+	// Attempt is this attempt's 1-based sequence number for TaskName within
+	// ExecutionID.
+	Attempt int32
+}
+
+// ExecutionCounters is the subset of Execution that UpdateExecutionCounters
+// can revise after RecordExecutionStart, so callers don't need to re-supply
+// immutable fields like DAGName/Trigger on every status change.
+type ExecutionCounters struct {
+	Status     workflowv1.TaskState
+	EndTime    *time.Time
+	Total      int32
+	Failed     int32
+	Succeeded  int32
+	InProgress int32
+	Stopped    int32
+}
+
+// HistoryStore persists Execution and TaskAttempt records and serves the
+// read queries UIs, retries and SLA tooling need on top of them.
+type HistoryStore interface {
+	// RecordExecutionStart creates exec. Calling it twice for the same ID
+	// is an error; use UpdateExecutionCounters for subsequent changes.
+	RecordExecutionStart(ctx context.Context, exec *Execution) error
+
+	// UpdateExecutionCounters applies counters to the Execution identified
+	// by executionID.
+	UpdateExecutionCounters(ctx context.Context, executionID string, counters ExecutionCounters) error
+
+	// RecordTaskAttempt creates or replaces the TaskAttempt identified by
+	// attempt.ID.
+	RecordTaskAttempt(ctx context.Context, attempt *TaskAttempt) error
+
+	// ListExecutions returns dagName's executions, most recent StartTime
+	// first, capped at limit (0 means no cap).
+	ListExecutions(ctx context.Context, dagName string, limit int) ([]*Execution, error)
+
+	// GetTaskAttempts returns every TaskAttempt recorded for executionID, in
+	// no particular guaranteed order.
+	GetTaskAttempts(ctx context.Context, executionID string) ([]*TaskAttempt, error)
+}
+
+// Config configures New's choice of backend.
+type Config struct {
+	// Type selects the registered backend.
+	Type StoreType
+	// ConnectionString is passed through verbatim to the backend, e.g. a
+	// postgres DSN. Unused by StoreTypeMemory.
+	ConnectionString string
+}
+
+// StoreType identifies a history.Store backend.
+type StoreType string
+
+const (
+	// StoreTypeMemory uses in-memory storage (for testing and single-replica
+	// dev deployments; see history/memory).
+	StoreTypeMemory StoreType = "memory"
+)
+
+// Factory builds a Store for a given Config. Backends register their
+// Factory via Register, typically from an init() in their package, mirroring
+// internal/store.Factory.
+type Factory func(ctx context.Context, cfg Config) (HistoryStore, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[StoreType]Factory)
+)
+
+// Register makes a Store backend available under the given StoreType. It
+// panics if called twice for the same type, mirroring internal/store.Register.
+func Register(t StoreType, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if _, exists := factories[t]; exists {
+		panic(fmt.Sprintf("history: Register called twice for type %q", t))
+	}
+	factories[t] = factory
+}
+
+// New creates a Store for cfg.Type, returning an error if no backend has
+// been registered for that type (usually because its package was never
+// blank-imported).
+func New(ctx context.Context, cfg Config) (HistoryStore, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[cfg.Type]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("history: no backend registered for type %q (forgot to import it?)", cfg.Type)
+	}
+	return factory(ctx, cfg)
+}
@@ -0,0 +1,119 @@
+// Package memory provides an in-memory implementation of history.
+// HistoryStore, primarily intended for tests and single-replica dev
+// deployments. It mirrors internal/store/memory's approach of the same
+// name for the same reason.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kination/nautikus/internal/history"
+)
+
+func init() {
+	history.Register(history.StoreTypeMemory, func(ctx context.Context, cfg history.Config) (history.HistoryStore, error) {
+		return New(), nil
+	})
+}
+
+// Store implements history.HistoryStore backed by in-process maps. It is
+// not persisted across restarts.
+type Store struct {
+	mu         sync.RWMutex
+	executions map[string]*history.Execution
+	attempts   map[string][]*history.TaskAttempt // executionID -> attempts
+}
+
+// New creates a new in-memory Store.
+func New() *Store {
+	return &Store{
+		executions: make(map[string]*history.Execution),
+		attempts:   make(map[string][]*history.TaskAttempt),
+	}
+}
+
+// RecordExecutionStart implements history.HistoryStore.
+func (s *Store) RecordExecutionStart(ctx context.Context, exec *history.Execution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.executions[exec.ID]; exists {
+		return fmt.Errorf("execution %s already recorded", exec.ID)
+	}
+	copied := *exec
+	s.executions[exec.ID] = &copied
+	return nil
+}
+
+// UpdateExecutionCounters implements history.HistoryStore.
+func (s *Store) UpdateExecutionCounters(ctx context.Context, executionID string, counters history.ExecutionCounters) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exec, ok := s.executions[executionID]
+	if !ok {
+		return fmt.Errorf("execution %s not found", executionID)
+	}
+	exec.Status = counters.Status
+	exec.EndTime = counters.EndTime
+	exec.Total = counters.Total
+	exec.Failed = counters.Failed
+	exec.Succeeded = counters.Succeeded
+	exec.InProgress = counters.InProgress
+	exec.Stopped = counters.Stopped
+	return nil
+}
+
+// RecordTaskAttempt implements history.HistoryStore.
+func (s *Store) RecordTaskAttempt(ctx context.Context, attempt *history.TaskAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *attempt
+	for i, existing := range s.attempts[attempt.ExecutionID] {
+		if existing.ID == attempt.ID {
+			s.attempts[attempt.ExecutionID][i] = &copied
+			return nil
+		}
+	}
+	s.attempts[attempt.ExecutionID] = append(s.attempts[attempt.ExecutionID], &copied)
+	return nil
+}
+
+// ListExecutions implements history.HistoryStore.
+func (s *Store) ListExecutions(ctx context.Context, dagName string, limit int) ([]*history.Execution, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*history.Execution
+	for _, exec := range s.executions {
+		if exec.DAGName != dagName {
+			continue
+		}
+		copied := *exec
+		out = append(out, &copied)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.After(out[j].StartTime) })
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// GetTaskAttempts implements history.HistoryStore.
+func (s *Store) GetTaskAttempts(ctx context.Context, executionID string) ([]*history.TaskAttempt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attempts := s.attempts[executionID]
+	out := make([]*history.TaskAttempt, 0, len(attempts))
+	for _, a := range attempts {
+		copied := *a
+		out = append(out, &copied)
+	}
+	return out, nil
+}
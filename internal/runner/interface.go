@@ -4,18 +4,26 @@ package runner
 
 import (
 	"context"
+	"time"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 )
 
 // Runner defines the interface for task execution.
 // It receives scheduled tasks and uses executors to run them.
+//
+// Every method takes both the Dag (the template) and the DagRun (the
+// execution instance being advanced), so task execution is scoped to one
+// run and concurrent runs of the same Dag don't collide on resource names.
 type Runner interface {
-	// Run executes a task and returns the result
-	Run(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (*RunResult, error)
+	// Run executes a task within run and returns the result
+	Run(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) (*RunResult, error)
 
-	// GetStatus checks the current status of a running task
-	GetStatus(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (workflowv1.TaskState, error)
+	// GetStatus checks the current status of a running task within run
+	GetStatus(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) (workflowv1.TaskState, error)
+
+	// Cleanup removes any resources created for the task (Pod, external CR, ...)
+	Cleanup(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) error
 }
 
 // RunResult contains the result of task execution
@@ -31,6 +39,14 @@ type RunResult struct {
 
 	// Message contains any additional information
 	Message string
+
+	// Attempts is the number of times this task has been run so far,
+	// including the attempt this result describes.
+	Attempts int
+
+	// RetryAfter is set when the task failed but TaskSpec.RetryPolicy still
+	// allows a retry; the caller should re-invoke Run after this duration.
+	RetryAfter time.Duration
 }
 
 // RunnerConfig holds configuration for the runner
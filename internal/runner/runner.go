@@ -3,18 +3,51 @@ package runner
 import (
 	"context"
 	"fmt"
+	"time"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/branch"
+	"github.com/kination/nautikus/internal/connector"
 	"github.com/kination/nautikus/internal/executor"
+	"github.com/kination/nautikus/internal/store"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var log = ctrl.Log.WithName("runner")
 
+// defaultBackoffFactor is used when a RunnerConfig-derived retry policy
+// doesn't specify one explicitly.
+const defaultBackoffFactor = 2.0
+
 // DefaultRunner implements the Runner interface using the executor registry.
+// Tasks that declare a TaskSpec.Ref are dispatched to the connector registry
+// instead, so third parties can plug in new execution backends without
+// touching the executor registry.
 type DefaultRunner struct {
-	executorRegistry *executor.Registry
-	config           RunnerConfig
+	executorRegistry  *executor.Registry
+	connectorRegistry *connector.Registry
+	client            client.Client
+	store             store.Store
+	events            store.EventStore
+	config            RunnerConfig
+}
+
+// WithStore attaches a store.Store so DAGRun/TaskRun transitions are
+// persisted as the runner observes them, letting history survive etcd
+// compaction even if the Dag object itself is later garbage collected.
+func (r *DefaultRunner) WithStore(s store.Store) *DefaultRunner {
+	r.store = s
+	return r
+}
+
+// WithEventStore attaches a store.EventStore so task lifecycle transitions
+// are published as EventTypeTask* events for external subscribers.
+func (r *DefaultRunner) WithEventStore(es store.EventStore) *DefaultRunner {
+	r.events = es
+	return r
 }
 
 // NewRunner creates a new DefaultRunner with the given executor registry
@@ -30,42 +63,501 @@ func NewDefaultRunner(registry *executor.Registry) *DefaultRunner {
 	return NewRunner(registry, DefaultRunnerConfig())
 }
 
-// Run executes a task using the appropriate executor
-func (r *DefaultRunner) Run(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (*RunResult, error) {
+// NewRunnerWithConnectors creates a DefaultRunner that can also dispatch
+// TaskSpec.Ref tasks to an ExternalResourceConnector. cl is used to create,
+// read and delete the unstructured custom resources the connectors build.
+func NewRunnerWithConnectors(registry *executor.Registry, connectors *connector.Registry, cl client.Client, config RunnerConfig) *DefaultRunner {
+	return &DefaultRunner{
+		executorRegistry:  registry,
+		connectorRegistry: connectors,
+		client:            cl,
+		config:            config,
+	}
+}
+
+// Run executes a task within run using the appropriate executor, or
+// dispatches it to an ExternalResourceConnector when the task declares a Ref
+// or is TaskTypeCustom. TaskTypeRun tasks are excluded from that dispatch
+// even though they also set Ref: they go through the executor registry's
+// custom.Executor instead, since they need no registered connector.
+func (r *DefaultRunner) Run(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) (*RunResult, error) {
+	if task.Type != workflowv1.TaskTypeRun && (task.Ref != nil || task.Type == workflowv1.TaskTypeCustom) {
+		return r.runExternal(ctx, dag, run, task)
+	}
+
 	// Get the executor for this task type
 	exec, err := r.executorRegistry.Get(task.Type)
 	if err != nil {
 		return nil, fmt.Errorf("no executor found for task type %s: %w", task.Type, err)
 	}
 
-	log.Info("Running task", "dag", dag.Name, "task", task.Name, "type", task.Type)
+	log.Info("Running task", "dag", dag.Name, "run", runID(run), "task", task.Name, "type", task.Type)
+
+	runCtx := ctx
+	if task.Timeout != nil {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, task.Timeout.Duration)
+		defer cancel()
+	}
+
+	podName := fmt.Sprintf("%s-%s", runID(run), task.Name)
 
 	// Execute the task
-	if err := exec.Execute(ctx, dag, task); err != nil {
-		return &RunResult{
+	if err := exec.Execute(runCtx, dag, executor.SpecOf(dag.Name, task), executor.DataOf(task)); err != nil {
+		attempts := r.previousAttempts(ctx, dag, run, task.Name) + 1
+		result := &RunResult{
 			TaskName: task.Name,
-			PodName:  fmt.Sprintf("%s-%s", dag.Name, task.Name),
+			PodName:  podName,
 			State:    workflowv1.StateFailed,
 			Message:  err.Error(),
-		}, err
+			Attempts: attempts,
+		}
+
+		if policy := r.effectiveRetryPolicy(task); policy != nil && attempts < policy.MaxAttempts && policy.IsRetryable(err) {
+			backoff := policy.NextBackoff(attempts - 1)
+			result.State = workflowv1.StatePending
+			result.RetryAfter = backoff
+			result.Message = fmt.Sprintf("attempt %d/%d failed, retrying in %s: %v", attempts, policy.MaxAttempts, backoff, err)
+			r.persistAttempt(ctx, dag, run, result)
+			r.emit(ctx, dag, task.Name, store.EventTypeTaskFailed, map[string]interface{}{"error": err.Error(), "attempt": attempts})
+			return result, nil
+		}
+
+		r.persistAttempt(ctx, dag, run, result)
+		r.emit(ctx, dag, task.Name, store.EventTypeTaskFailed, map[string]interface{}{"error": err.Error(), "attempt": attempts})
+		return result, err
 	}
 
-	return &RunResult{
+	result := &RunResult{
 		TaskName: task.Name,
-		PodName:  fmt.Sprintf("%s-%s", dag.Name, task.Name),
+		PodName:  podName,
 		State:    workflowv1.StatePending,
 		Message:  "Task started",
-	}, nil
+		Attempts: r.previousAttempts(ctx, dag, run, task.Name) + 1,
+	}
+	r.persistAttempt(ctx, dag, run, result)
+	r.emit(ctx, dag, task.Name, store.EventTypeTaskStarted, nil)
+	return result, nil
+}
+
+// previousAttempts looks up how many attempts a task has already made
+// through the attached store, so retries can be counted across separate
+// Run calls. Returns 0 when no store is attached or no status exists yet.
+func (r *DefaultRunner) previousAttempts(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, taskName string) int {
+	if r.store == nil {
+		return 0
+	}
+	status, err := r.store.GetTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), taskName)
+	if err != nil {
+		return 0
+	}
+	return status.Attempts
+}
+
+// runExternal builds the CR referenced by task.Ref (or task.CustomRef, for
+// TaskTypeCustom tasks) through the matching ExternalResourceConnector,
+// creates it owned by the DAG, and reports the initial state. The connector
+// itself is responsible for translating the object's status.conditions into
+// a workflowv1.TaskState on later GetStatus calls.
+func (r *DefaultRunner) runExternal(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) (*RunResult, error) {
+	if r.connectorRegistry == nil || r.client == nil {
+		return nil, fmt.Errorf("runner has no connector registry configured for task %s", task.Name)
+	}
+
+	key, err := taskConnectorKey(task)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := r.connectorRegistry.GetExternal(key)
+	if err != nil {
+		return nil, fmt.Errorf("no connector found for %s: %w", key, err)
+	}
+
+	obj, err := conn.BuildResource(task, dag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resource for task %s: %w", task.Name, err)
+	}
+
+	// Label the built resource so the ConnectorReconciler can find its way
+	// back to the Dag/DagRun/task it belongs to without the connector
+	// itself needing to know about DagRuns.
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["dag"] = dag.Name
+	labels["task"] = task.Name
+	if run != nil {
+		labels["dagrun"] = run.Name
+	}
+	obj.SetLabels(labels)
+
+	if err := controllerutil.SetControllerReference(dag, obj, r.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference on %s: %w", obj.GetName(), err)
+	}
+
+	log.Info("Dispatching task to external connector", "dag", dag.Name, "task", task.Name, "ref", task.Ref)
+
+	if err := r.client.Create(ctx, obj); err != nil {
+		result := &RunResult{
+			TaskName: task.Name,
+			PodName:  obj.GetName(),
+			State:    workflowv1.StateFailed,
+			Message:  err.Error(),
+		}
+		r.persist(ctx, dag, run, result)
+		return result, err
+	}
+
+	result := &RunResult{
+		TaskName: task.Name,
+		PodName:  obj.GetName(),
+		State:    workflowv1.StatePending,
+		Message:  "External resource created",
+	}
+	r.persist(ctx, dag, run, result)
+	return result, nil
 }
 
-// GetStatus retrieves the current status of a task
-func (r *DefaultRunner) GetStatus(ctx context.Context, dag *workflowv1.Dag, task *workflowv1.TaskSpec) (workflowv1.TaskState, error) {
+// GetStatus retrieves the current status of a task within run
+func (r *DefaultRunner) GetStatus(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) (workflowv1.TaskState, error) {
+	if task.Type != workflowv1.TaskTypeRun && (task.Ref != nil || task.Type == workflowv1.TaskTypeCustom) {
+		if r.connectorRegistry == nil || r.client == nil {
+			return workflowv1.StateFailed, fmt.Errorf("runner has no connector registry configured for task %s", task.Name)
+		}
+		key, err := taskConnectorKey(task)
+		if err != nil {
+			return workflowv1.StateFailed, err
+		}
+		conn, err := r.connectorRegistry.GetExternal(key)
+		if err != nil {
+			return workflowv1.StateFailed, fmt.Errorf("no connector found for %s: %w", key, err)
+		}
+		return conn.GetStatus(ctx, r.client, task, dag)
+	}
+
 	exec, err := r.executorRegistry.Get(task.Type)
 	if err != nil {
 		return workflowv1.StateFailed, fmt.Errorf("no executor found for task type %s: %w", task.Type, err)
 	}
 
-	return exec.GetStatus(ctx, dag, task)
+	state, err := exec.GetStatus(ctx, dag, executor.SpecOf(dag.Name, task), executor.DataOf(task))
+	if err != nil {
+		return state, err
+	}
+
+	if state == workflowv1.StateFailed {
+		return r.retryOrFail(ctx, dag, run, task, exec)
+	}
+
+	r.persist(ctx, dag, run, &RunResult{TaskName: task.Name, State: state})
+	if state == workflowv1.StateCompleted {
+		r.emit(ctx, dag, task.Name, store.EventTypeTaskCompleted, nil)
+		r.captureOutputs(ctx, dag, run, task, exec)
+		r.captureResults(ctx, dag, run, task, exec)
+		r.evaluateBranchRules(ctx, dag, run, task)
+	}
+	return state, nil
+}
+
+// captureOutputs asks exec for this task's outputs, if it implements
+// executor.OutputCapturer, and folds them into the task's persisted
+// TaskStatus so BranchRule expressions on other tasks can reference them.
+// Best-effort: a failure to capture or persist outputs is logged, not
+// returned, since it must never hold up the task's own Completed status.
+func (r *DefaultRunner) captureOutputs(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec, exec executor.Executor) {
+	if r.store == nil {
+		return
+	}
+
+	capturer, ok := exec.(executor.OutputCapturer)
+	if !ok {
+		return
+	}
+
+	outputs, err := capturer.Outputs(ctx, dag, task)
+	if err != nil {
+		log.Error(err, "failed to capture task outputs", "dag", dag.Name, "task", task.Name)
+		return
+	}
+	if len(outputs) == 0 {
+		return
+	}
+
+	status, err := r.store.GetTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), task.Name)
+	if err != nil {
+		status = &workflowv1.TaskStatus{Name: task.Name, State: workflowv1.StateCompleted}
+	}
+	status.Outputs = outputs
+	if err := r.store.SaveTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), task.Name, status); err != nil {
+		log.Error(err, "failed to persist task outputs", "dag", dag.Name, "task", task.Name)
+	}
+}
+
+// captureResults asks exec for this task's results, if it implements
+// executor.ResultsCapturer, and folds them into the task's persisted
+// TaskStatus so $(tasks.<name>.result.<key>) substitution (see
+// internal/substitution) can reference them. Best-effort, same as
+// captureOutputs: a failure here must never hold up the task's own
+// Completed status.
+func (r *DefaultRunner) captureResults(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec, exec executor.Executor) {
+	if r.store == nil {
+		return
+	}
+
+	capturer, ok := exec.(executor.ResultsCapturer)
+	if !ok {
+		return
+	}
+
+	results, err := capturer.Results(ctx, dag, task)
+	if err != nil {
+		log.Error(err, "failed to capture task results", "dag", dag.Name, "task", task.Name)
+		return
+	}
+	if len(results) == 0 {
+		return
+	}
+
+	status, err := r.store.GetTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), task.Name)
+	if err != nil {
+		status = &workflowv1.TaskStatus{Name: task.Name, State: workflowv1.StateCompleted}
+	}
+	status.Results = results
+	if err := r.store.SaveTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), task.Name, status); err != nil {
+		log.Error(err, "failed to persist task results", "dag", dag.Name, "task", task.Name)
+	}
+}
+
+// evaluateBranchRules runs once a task with BranchRules completes. The
+// first rule whose Expression evaluates true selects a branch; every
+// sibling task tagged Env["NAUTIKUS_BRANCH_CONDITION"] with a different
+// branch is then marked StateSkipped so the scheduler never waits on it.
+func (r *DefaultRunner) evaluateBranchRules(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) {
+	if len(task.BranchRules) == 0 || r.store == nil {
+		return
+	}
+
+	statuses, err := r.taskStatusSnapshot(ctx, dag, run)
+	if err != nil {
+		log.Error(err, "failed to snapshot task statuses for branch evaluation", "dag", dag.Name, "task", task.Name)
+		return
+	}
+
+	var selected string
+	for _, rule := range task.BranchRules {
+		ok, err := branch.Evaluate(rule.Expression, statuses)
+		if err != nil {
+			log.Error(err, "failed to evaluate branch rule", "dag", dag.Name, "task", task.Name, "expression", rule.Expression)
+			continue
+		}
+		if ok {
+			selected = rule.TargetBranch
+			break
+		}
+	}
+	if selected == "" {
+		return
+	}
+
+	for _, sibling := range dag.Spec.Tasks {
+		branchName, tagged := sibling.Env["NAUTIKUS_BRANCH_CONDITION"]
+		if !tagged || branchName == selected {
+			continue
+		}
+		r.persist(ctx, dag, run, &RunResult{
+			TaskName: sibling.Name,
+			State:    workflowv1.StateSkipped,
+			Message:  fmt.Sprintf("branch %q not selected by %s", branchName, task.Name),
+		})
+		r.emit(ctx, dag, sibling.Name, store.EventTypeTaskSkipped, map[string]interface{}{"branch": branchName})
+	}
+}
+
+// taskStatusSnapshot builds the tasks.<name> lookup table branch.Evaluate
+// needs from every TaskStatus currently persisted for dag's run.
+func (r *DefaultRunner) taskStatusSnapshot(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun) (map[string]workflowv1.TaskStatus, error) {
+	list, err := r.store.ListTaskStatuses(ctx, dag.Namespace, dag.Name, runID(run))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]workflowv1.TaskStatus, len(list))
+	for _, s := range list {
+		statuses[s.Name] = s
+	}
+	return statuses, nil
+}
+
+// retryOrFail is called once GetStatus observes a Failed executor-reported
+// task. It mirrors the retry bookkeeping Run does for synchronous failures,
+// but here the failure was only discovered on a later poll: the task stays
+// Pending with RetryAfter set so the caller's reconcile loop re-invokes Run
+// after the backoff instead of the runner blocking the poll on a sleep.
+func (r *DefaultRunner) retryOrFail(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec, exec executor.Executor) (workflowv1.TaskState, error) {
+	// Run already stored this attempt's number when it started the task.
+	attempts := r.previousAttempts(ctx, dag, run, task.Name)
+	if attempts == 0 {
+		attempts = 1
+	}
+	taskErr := fmt.Errorf("task %s reported failed status", task.Name)
+
+	if policy := r.effectiveRetryPolicy(task); policy != nil && attempts < policy.MaxAttempts && policy.IsRetryable(taskErr) {
+		backoff := policy.NextBackoff(attempts - 1)
+
+		// Clear out the failed resource so the next Run can recreate it
+		// under the same name.
+		if err := exec.Cleanup(ctx, dag, executor.SpecOf(dag.Name, task), executor.DataOf(task)); err != nil {
+			log.Error(err, "failed to clean up failed task before retry", "dag", dag.Name, "task", task.Name)
+		}
+
+		result := &RunResult{
+			TaskName:   task.Name,
+			State:      workflowv1.StatePending,
+			Message:    fmt.Sprintf("attempt %d/%d failed, retrying in %s", attempts, policy.MaxAttempts, backoff),
+			Attempts:   attempts,
+			RetryAfter: backoff,
+		}
+		r.persistAttempt(ctx, dag, run, result)
+		r.emit(ctx, dag, task.Name, store.EventTypeTaskFailed, map[string]interface{}{"attempt": attempts})
+		return workflowv1.StatePending, nil
+	}
+
+	r.persistAttempt(ctx, dag, run, &RunResult{TaskName: task.Name, State: workflowv1.StateFailed, Attempts: attempts, Message: taskErr.Error()})
+	r.emit(ctx, dag, task.Name, store.EventTypeTaskFailed, map[string]interface{}{"attempt": attempts})
+	return workflowv1.StateFailed, nil
+}
+
+// effectiveRetryPolicy returns task.RetryPolicy if set, otherwise synthesizes
+// one from the runner's RunnerConfig so MaxRetries/RetryBackoffSeconds act as
+// a cluster-wide default that per-task policies can override. Returns nil
+// when neither is configured, meaning failures are not retried.
+func (r *DefaultRunner) effectiveRetryPolicy(task *workflowv1.TaskSpec) *workflowv1.RetryPolicy {
+	if task.RetryPolicy != nil {
+		return task.RetryPolicy
+	}
+	if r.config.MaxRetries <= 0 {
+		return nil
+	}
+	return &workflowv1.RetryPolicy{
+		MaxAttempts:    r.config.MaxRetries + 1,
+		InitialBackoff: metav1.Duration{Duration: time.Duration(r.config.RetryBackoffSeconds) * time.Second},
+		BackoffFactor:  defaultBackoffFactor,
+	}
+}
+
+// persist records the task's current state through the attached store, if
+// any, keeping the attempt count already stored for the task when result
+// carries none. Failures are logged rather than propagated: history is
+// best-effort and must never block task execution.
+func (r *DefaultRunner) persist(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, result *RunResult) {
+	if result.Attempts == 0 {
+		withAttempts := *result
+		withAttempts.Attempts = r.previousAttempts(ctx, dag, run, result.TaskName)
+		result = &withAttempts
+	}
+	r.persistAttempt(ctx, dag, run, result)
+}
+
+// persistAttempt is like persist but also carries Attempts/LastError, used
+// by the retry path so TaskStatus reflects the current attempt count.
+func (r *DefaultRunner) persistAttempt(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, result *RunResult) {
+	if r.store == nil {
+		return
+	}
+
+	status := &workflowv1.TaskStatus{
+		Name:     result.TaskName,
+		State:    result.State,
+		PodName:  result.PodName,
+		Message:  result.Message,
+		Attempts: result.Attempts,
+	}
+	if result.State == workflowv1.StateFailed || result.RetryAfter > 0 {
+		status.LastError = result.Message
+	}
+	if err := r.store.SaveTaskStatus(ctx, dag.Namespace, dag.Name, runID(run), result.TaskName, status); err != nil {
+		log.Error(err, "failed to persist task status", "dag", dag.Name, "task", result.TaskName)
+	}
+}
+
+// emit publishes a workflow event through the attached EventStore, if any.
+// Like persist, this is best-effort: a broken event bus must never block
+// task execution.
+func (r *DefaultRunner) emit(ctx context.Context, dag *workflowv1.Dag, taskName string, eventType store.EventType, data map[string]interface{}) {
+	if r.events == nil {
+		return
+	}
+
+	event := &store.Event{
+		ID:           fmt.Sprintf("%s/%s/%s/%d", dag.Namespace, dag.Name, taskName, time.Now().UnixNano()),
+		Type:         eventType,
+		Timestamp:    time.Now(),
+		DAGNamespace: dag.Namespace,
+		DAGName:      dag.Name,
+		TaskName:     taskName,
+		Data:         data,
+	}
+	if err := r.events.Publish(ctx, event); err != nil {
+		log.Error(err, "failed to publish task event", "dag", dag.Name, "task", taskName, "type", eventType)
+	}
+}
+
+// Cleanup removes any resources a task created, either through its executor
+// or, for Ref tasks, through the owning ExternalResourceConnector. Intended
+// to be called when the DAG is deleted and OwnerReference garbage collection
+// isn't fast enough or applicable (e.g. cluster-scoped CRs).
+func (r *DefaultRunner) Cleanup(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun, task *workflowv1.TaskSpec) error {
+	if task.Type != workflowv1.TaskTypeRun && (task.Ref != nil || task.Type == workflowv1.TaskTypeCustom) {
+		if r.connectorRegistry == nil || r.client == nil {
+			return fmt.Errorf("runner has no connector registry configured for task %s", task.Name)
+		}
+		key, err := taskConnectorKey(task)
+		if err != nil {
+			return err
+		}
+		conn, err := r.connectorRegistry.GetExternal(key)
+		if err != nil {
+			return fmt.Errorf("no connector found for %s: %w", key, err)
+		}
+		return conn.Cleanup(ctx, r.client, task, dag)
+	}
+
+	exec, err := r.executorRegistry.Get(task.Type)
+	if err != nil {
+		return fmt.Errorf("no executor found for task type %s: %w", task.Type, err)
+	}
+	return exec.Cleanup(ctx, dag, executor.SpecOf(dag.Name, task), executor.DataOf(task))
+}
+
+// taskConnectorKey builds the connector.Registry lookup key for a task
+// dispatched externally, whether via Ref (an existing-or-named CR) or
+// CustomRef (a TaskTypeCustom task with an inline connector payload).
+func taskConnectorKey(task *workflowv1.TaskSpec) (string, error) {
+	switch {
+	case task.Ref != nil:
+		return fmt.Sprintf("%s/%s", task.Ref.APIVersion, task.Ref.Kind), nil
+	case task.CustomRef != nil:
+		return fmt.Sprintf("%s/%s", task.CustomRef.APIVersion, task.CustomRef.Kind), nil
+	default:
+		return "", fmt.Errorf("task %s has no Ref or CustomRef to dispatch externally", task.Name)
+	}
+}
+
+// runID returns the identifier used to namespace resource names (Pods,
+// external CRs) to one execution instance, so concurrent runs of the same
+// Dag don't collide. Falls back to run.Name when UID isn't set yet (e.g. in
+// tests that build a DagRun without going through the API server), and to a
+// fixed placeholder when run is nil so single-run callers keep working.
+func runID(run *workflowv1.DagRun) string {
+	if run == nil {
+		return "run"
+	}
+	if run.UID != "" {
+		return string(run.UID)
+	}
+	return run.Name
 }
 
 // Config returns the runner configuration
@@ -5,7 +5,9 @@ package scheduler
 import (
 	"context"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/executor"
+	"github.com/kination/nautikus/internal/history"
 )
 
 // Policy defines the scheduling policy type
@@ -24,7 +26,7 @@ const (
 type TaskInfo struct {
 	DagName   string
 	TaskName  string
-	Priority  int32
+	Priority  workflowv1.TaskPriority
 	Resources ResourceRequirements
 }
 
@@ -46,13 +48,24 @@ type Scheduler interface {
 	// Policy returns the scheduling policy
 	Policy() Policy
 
-	// Schedule determines which tasks should be executed next
-	Schedule(ctx context.Context, dag *workflowv1.Dag) ([]workflowv1.TaskSpec, error)
+	// Schedule determines which tasks should be executed next for run
+	Schedule(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun) ([]workflowv1.TaskSpec, error)
 
 	// CanSchedule checks if a task can be scheduled based on resources and constraints
 	CanSchedule(ctx context.Context, task *TaskInfo) (bool, error)
 }
 
+// MultiDagScheduler is implemented by schedulers that can weigh fairness
+// across several Dags at once, something Scheduler.Schedule can't express
+// since it only ever sees one Dag/DagRun pair per call.
+type MultiDagScheduler interface {
+	// ScheduleFairShare runs one weighted deficit round-robin round across
+	// dags (see DefaultScheduler.applyFairShare/SchedulerConfig.FairShare),
+	// returning the tasks to run next keyed by Dag name. runs must carry
+	// one entry per dags[i].Name; a Dag missing from runs is skipped.
+	ScheduleFairShare(ctx context.Context, dags []*workflowv1.Dag, runs map[string]*workflowv1.DagRun) (map[string][]workflowv1.TaskSpec, error)
+}
+
 // Queue defines the interface for task queuing
 type Queue interface {
 	// Enqueue adds a task to the queue
@@ -70,15 +83,57 @@ type Queue interface {
 
 // SchedulerConfig holds common configuration for schedulers
 type SchedulerConfig struct {
-	Policy           Policy
+	Policy            Policy
 	MaxConcurrentDAGs int32
 	MaxActiveTasks    int32
+
+	// MaxActiveTasksPerDag caps how many tasks from a single DagRun may be
+	// active at once, independent of MaxActiveTasks' cross-DAG total. Zero
+	// means no per-DAG cap beyond MaxActiveTasks itself.
+	MaxActiveTasksPerDag int32
+
+	// BucketCaps limits how many tasks from a single DagRun may be active
+	// within a given priority bucket at once. Only consulted when Policy is
+	// PolicyPriority; a priority absent from the map falls back to
+	// defaultBucketCap.
+	BucketCaps map[workflowv1.TaskPriority]int32
+
+	// FairShare configures PolicyFairShare's weighted deficit round-robin
+	// across Dags. Only consulted when Policy is PolicyFairShare.
+	FairShare FairShareConfig
+
+	// Registry resolves the executor.Executor for a TaskTypeSysBatch
+	// candidate so Schedule/ScheduleFairShare can fan it out via
+	// Executor.Instances (see fanOutSysBatch). Nil disables fanout: a
+	// TaskTypeSysBatch task is simply never offered as a candidate.
+	Registry *executor.Registry
+
+	// History, if set, receives a TaskAttempt record from every
+	// NotifyTaskStarted/NotifyTaskCompleted call (see DefaultScheduler).
+	// Nil disables history recording entirely.
+	History history.HistoryStore
+}
+
+// FairShareConfig configures PolicyFairShare's weighted deficit
+// round-robin across Dags (see DefaultScheduler.applyFairShare). Every
+// candidate task costs 1 unit of deficit; TaskSpec carries no per-task
+// cost field today.
+type FairShareConfig struct {
+	// Quantum is added to an active Dag's deficit, multiplied by its
+	// weight, once per Schedule/ScheduleFairShare round. Defaults to 1
+	// when zero.
+	Quantum int32
+	// DefaultWeight is used for a Dag absent from Weights. Defaults to 1
+	// when zero.
+	DefaultWeight int32
+	// Weights overrides DefaultWeight for specific Dag names.
+	Weights map[string]int32
 }
 
 // DefaultSchedulerConfig returns the default scheduler configuration
 func DefaultSchedulerConfig() SchedulerConfig {
 	return SchedulerConfig{
-		Policy:           PolicyFIFO,
+		Policy:            PolicyFIFO,
 		MaxConcurrentDAGs: 100,
 		MaxActiveTasks:    10,
 	}
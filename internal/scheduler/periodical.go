@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// PeriodicalScheduler complements FIFO/Priority/FairShare: it still uses
+// DefaultScheduler's policy to decide which tasks run next inside a DagRun,
+// but additionally owns the Trigger sources that decide when a new DagRun
+// should be created for a Dag in the first place (manual submission, cron
+// cadence, or an external event).
+type PeriodicalScheduler struct {
+	*DefaultScheduler
+
+	cron     *CronTrigger
+	manual   *ManualTrigger
+	event    *EventTrigger
+	interval *IntervalTrigger
+	triggers []Trigger
+}
+
+// NewPeriodicalScheduler creates a PeriodicalScheduler with Manual, Cron,
+// Event and Interval trigger sources registered, delegating task-level
+// scheduling to a DefaultScheduler configured with config.
+func NewPeriodicalScheduler(config SchedulerConfig) *PeriodicalScheduler {
+	cronTrigger := NewCronTrigger()
+	manualTrigger := NewManualTrigger()
+	eventTrigger := NewEventTrigger()
+	intervalTrigger := NewIntervalTrigger()
+
+	return &PeriodicalScheduler{
+		DefaultScheduler: NewScheduler(config),
+		cron:             cronTrigger,
+		manual:           manualTrigger,
+		event:            eventTrigger,
+		interval:         intervalTrigger,
+		triggers:         []Trigger{manualTrigger, cronTrigger, eventTrigger, intervalTrigger},
+	}
+}
+
+// RegisterDag adds or updates dag's cron and interval entries based on its
+// DagSpec.Schedule. Safe to call again when the Dag spec changes (e.g. a new
+// cron expression or trigger policy); each trigger clears its own entry when
+// the field it cares about is unset, so only one ever actually fires for a
+// given Dag (see ScheduleSpec's CronExpression/TriggerPolicy doc comment).
+func (s *PeriodicalScheduler) RegisterDag(dag *workflowv1.Dag, fire FireFunc) error {
+	if err := s.cron.Register(dag, fire); err != nil {
+		return err
+	}
+	return s.interval.Register(dag, fire)
+}
+
+// Manual returns the trigger source for directly-submitted DagRuns.
+func (s *PeriodicalScheduler) Manual() *ManualTrigger { return s.manual }
+
+// Event returns the trigger source for externally-notified DagRuns.
+func (s *PeriodicalScheduler) Event() *EventTrigger { return s.event }
+
+// Interval returns the trigger source for wall-clock recurring DagRuns
+// (DagSpec.Schedule.TriggerPolicy), the alternative to Cron's expression-based
+// schedules.
+func (s *PeriodicalScheduler) Interval() *IntervalTrigger { return s.interval }
+
+// Start runs every registered trigger source until ctx is cancelled,
+// invoking fire for each one that decides a DagRun should be created.
+func (s *PeriodicalScheduler) Start(ctx context.Context, fire FireFunc) error {
+	errCh := make(chan error, len(s.triggers))
+	for _, t := range s.triggers {
+		go func(t Trigger) {
+			errCh <- t.Start(ctx, fire)
+		}(t)
+	}
+
+	for range s.triggers {
+		if err := <-errCh; err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Stop asks every trigger source to stop.
+func (s *PeriodicalScheduler) Stop() {
+	for _, t := range s.triggers {
+		t.Stop()
+	}
+}
+
+// ListPending returns the next scheduled fire time for every Dag with an
+// active cron or interval trigger, so operators have a single place to
+// audit upcoming executions.
+func (s *PeriodicalScheduler) ListPending() map[string]time.Time {
+	out := s.cron.ListPending()
+	for dagName, next := range s.interval.ListPending() {
+		out[dagName] = next
+	}
+	return out
+}
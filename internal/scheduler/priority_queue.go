@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// ErrTaskQueueFull is returned when a task cannot be enqueued because its
+// priority bucket, or the queue's overall capacity, has no room left.
+var ErrTaskQueueFull = errors.New("scheduler: task queue full")
+
+// bucketOrder lists the priority buckets from highest to lowest, the order
+// PriorityQueue drains them in and DefaultScheduler.Schedule applies under
+// PolicyPriority.
+var bucketOrder = []workflowv1.TaskPriority{
+	workflowv1.PriorityUrgent,
+	workflowv1.PriorityHigh,
+	workflowv1.PriorityNormal,
+	workflowv1.PriorityLow,
+}
+
+// defaultBucketCap is the cap applied to a priority bucket absent from
+// SchedulerConfig.BucketCaps/PriorityQueueConfig.BucketCaps.
+const defaultBucketCap = int32(10)
+
+// bucketOf normalizes p to one of bucketOrder's values, treating anything
+// else (including the empty TaskPriority zero value) as PriorityNormal.
+func bucketOf(p workflowv1.TaskPriority) workflowv1.TaskPriority {
+	switch p {
+	case workflowv1.PriorityLow, workflowv1.PriorityHigh, workflowv1.PriorityUrgent:
+		return p
+	default:
+		return workflowv1.PriorityNormal
+	}
+}
+
+// bucketRank returns bucketOf(p)'s index into bucketOrder: 0 for the
+// highest priority bucket, increasing as priority drops.
+func bucketRank(p workflowv1.TaskPriority) int {
+	b := bucketOf(p)
+	for i, o := range bucketOrder {
+		if o == b {
+			return i
+		}
+	}
+	return len(bucketOrder) - 1
+}
+
+// bucketCap returns caps[bucketOf(p)], falling back to defaultBucketCap
+// when the bucket has no explicit entry.
+func bucketCap(caps map[workflowv1.TaskPriority]int32, p workflowv1.TaskPriority) int32 {
+	if c, ok := caps[bucketOf(p)]; ok {
+		return c
+	}
+	return defaultBucketCap
+}
+
+// PriorityQueueConfig configures a PriorityQueue's capacity.
+type PriorityQueueConfig struct {
+	// BucketCaps limits how many TaskInfos may sit in each priority bucket
+	// at once. A priority absent from the map uses defaultBucketCap.
+	BucketCaps map[workflowv1.TaskPriority]int32
+	// GlobalCap limits the queue's total size across every bucket
+	// combined. Zero means unlimited.
+	GlobalCap int32
+}
+
+// PriorityQueue implements Queue as N FIFO buckets, one per
+// workflowv1.TaskPriority, always dequeued in bucketOrder (Urgent first,
+// Low last). It backs DefaultScheduler's PolicyPriority policy, both for
+// the tasks Schedule re-offers after a Preempt and as a reusable building
+// block for standalone priority queuing.
+type PriorityQueue struct {
+	mu      sync.Mutex
+	config  PriorityQueueConfig
+	buckets map[workflowv1.TaskPriority][]*TaskInfo
+	size    int32
+}
+
+// NewPriorityQueue creates an empty PriorityQueue.
+func NewPriorityQueue(config PriorityQueueConfig) *PriorityQueue {
+	return &PriorityQueue{
+		config:  config,
+		buckets: make(map[workflowv1.TaskPriority][]*TaskInfo),
+	}
+}
+
+// Enqueue adds task to its priority bucket (task.Priority, normalized via
+// bucketOf), returning ErrTaskQueueFull if that bucket or the queue's
+// GlobalCap has no room left.
+func (q *PriorityQueue) Enqueue(task *TaskInfo) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.config.GlobalCap > 0 && q.size >= q.config.GlobalCap {
+		return ErrTaskQueueFull
+	}
+
+	bucket := bucketOf(task.Priority)
+	if int32(len(q.buckets[bucket])) >= bucketCap(q.config.BucketCaps, bucket) {
+		return ErrTaskQueueFull
+	}
+
+	q.buckets[bucket] = append(q.buckets[bucket], task)
+	q.size++
+	return nil
+}
+
+// EnqueueFront adds task to the front of its priority bucket instead of the
+// back, for a task that's being offered again (e.g. after Preempt) rather
+// than arriving fresh, so it's dequeued ahead of its same-bucket FIFO peers.
+// Subject to the same capacity checks as Enqueue.
+func (q *PriorityQueue) EnqueueFront(task *TaskInfo) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.config.GlobalCap > 0 && q.size >= q.config.GlobalCap {
+		return ErrTaskQueueFull
+	}
+
+	bucket := bucketOf(task.Priority)
+	if int32(len(q.buckets[bucket])) >= bucketCap(q.config.BucketCaps, bucket) {
+		return ErrTaskQueueFull
+	}
+
+	q.buckets[bucket] = append([]*TaskInfo{task}, q.buckets[bucket]...)
+	q.size++
+	return nil
+}
+
+// Dequeue removes and returns the task at the front of the highest-priority
+// non-empty bucket, or (nil, nil) if the queue is empty.
+func (q *PriorityQueue) Dequeue() (*TaskInfo, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, bucket := range bucketOrder {
+		tasks := q.buckets[bucket]
+		if len(tasks) == 0 {
+			continue
+		}
+		task := tasks[0]
+		q.buckets[bucket] = tasks[1:]
+		q.size--
+		return task, nil
+	}
+	return nil, nil
+}
+
+// Peek returns the task Dequeue would return next, without removing it.
+func (q *PriorityQueue) Peek() (*TaskInfo, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, bucket := range bucketOrder {
+		if tasks := q.buckets[bucket]; len(tasks) > 0 {
+			return tasks[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// Len returns the queue's total size across every bucket.
+func (q *PriorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int(q.size)
+}
+
+// DrainDag removes and returns every queued TaskInfo belonging to dagName,
+// in bucketOrder, leaving other Dags' entries untouched.
+func (q *PriorityQueue) DrainDag(dagName string) []*TaskInfo {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var drained []*TaskInfo
+	for _, bucket := range bucketOrder {
+		var kept []*TaskInfo
+		for _, t := range q.buckets[bucket] {
+			if t.DagName == dagName {
+				drained = append(drained, t)
+				q.size--
+			} else {
+				kept = append(kept, t)
+			}
+		}
+		q.buckets[bucket] = kept
+	}
+	return drained
+}
+
+var _ Queue = (*PriorityQueue)(nil)
@@ -3,9 +3,13 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/history"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -17,15 +21,45 @@ type DefaultScheduler struct {
 	config SchedulerConfig
 
 	// Track active tasks for concurrency control
-	activeTasksPerDAG map[string]int
-	totalActiveTasks  int
+	activeTasksPerDAG   map[string]int
+	activeTasksByBucket map[workflowv1.TaskPriority]int
+	totalActiveTasks    int
+
+	// runningTasks tracks every task NotifyTaskStarted was called for and
+	// not yet NotifyTaskCompleted, so Preempt can pick a victim by priority.
+	runningTasks map[string]*TaskInfo
+
+	// preempted holds tasks Preempt pulled out of StateRunning, offered
+	// back to Schedule ahead of same-bucket FIFO peers on its next call.
+	preempted *PriorityQueue
+
+	// deficits holds each Dag's accumulated WDRR deficit for PolicyFairShare,
+	// keyed by Dag name. See applyFairShare.
+	deficits map[string]int32
+
+	// attemptSeq counts NotifyTaskStarted calls per running-task key, so
+	// repeated retries of the same task produce distinct history.TaskAttempt
+	// records. Only consulted when s.config.History is set.
+	attemptSeq map[string]int32
+
+	// attemptStart holds the fields NotifyTaskStarted recorded for the
+	// current attempt, so NotifyTaskCompleted's RecordTaskAttempt call (a
+	// full replace, not a partial update) can resend them unchanged. Only
+	// consulted when s.config.History is set.
+	attemptStart map[string]history.TaskAttempt
 }
 
 // NewScheduler creates a new DefaultScheduler with the given configuration
 func NewScheduler(config SchedulerConfig) *DefaultScheduler {
 	return &DefaultScheduler{
-		config:            config,
-		activeTasksPerDAG: make(map[string]int),
+		config:              config,
+		activeTasksPerDAG:   make(map[string]int),
+		activeTasksByBucket: make(map[workflowv1.TaskPriority]int),
+		runningTasks:        make(map[string]*TaskInfo),
+		preempted:           NewPriorityQueue(PriorityQueueConfig{BucketCaps: config.BucketCaps}),
+		deficits:            make(map[string]int32),
+		attemptSeq:          make(map[string]int32),
+		attemptStart:        make(map[string]history.TaskAttempt),
 	}
 }
 
@@ -49,45 +83,102 @@ func (s *DefaultScheduler) Config() SchedulerConfig {
 	return s.config
 }
 
-// Schedule determines which tasks should be executed next for a given DAG.
-func (s *DefaultScheduler) Schedule(ctx context.Context, dag *workflowv1.Dag) ([]workflowv1.TaskSpec, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// runningKey identifies a running task in s.runningTasks.
+func runningKey(dagName, taskName string) string {
+	return dagName + "/" + taskName
+}
 
-	// Build status map for quick lookup
+// taskSpecByName looks up name among dag.Spec.Tasks and dag.Spec.Finally.
+func taskSpecByName(dag *workflowv1.Dag, name string) (workflowv1.TaskSpec, bool) {
+	for _, t := range dag.Spec.Tasks {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	for _, t := range dag.Spec.Finally {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return workflowv1.TaskSpec{}, false
+}
+
+// readyCandidates returns dag's tasks whose dependencies are satisfied and
+// that run hasn't already scheduled, plus how many of run's tasks are
+// currently active (Running or Pending). A TaskTypeSysBatch task ready to
+// start is expanded into one clone per node (see fanOutSysBatch) rather
+// than returned as-is; a TaskTypeSysBatch task already fanned out (some
+// "name--node" status exists) is treated as started and aggregateState
+// resolves its effective state for any downstream dependency check.
+func (s *DefaultScheduler) readyCandidates(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun) ([]workflowv1.TaskSpec, int, error) {
 	statusMap := make(map[string]workflowv1.TaskState)
-	for _, ts := range dag.Status.TaskStatuses {
+	for _, ts := range run.Status.TaskStatuses {
 		statusMap[ts.Name] = ts.State
 	}
 
-	// Count currently active tasks for this DAG
 	activeCount := 0
-	for _, ts := range dag.Status.TaskStatuses {
+	for _, ts := range run.Status.TaskStatuses {
 		if ts.State == workflowv1.StateRunning || ts.State == workflowv1.StatePending {
 			activeCount++
 		}
 	}
 
 	var candidates []workflowv1.TaskSpec
-
-	// Find tasks that are ready to run
 	for _, task := range dag.Spec.Tasks {
-		if _, exists := statusMap[task.Name]; exists {
+		if _, started := aggregateState(statusMap, task.Name); started {
 			continue
 		}
 
 		allDepsCompleted := true
 		for _, dep := range task.Dependencies {
-			if statusMap[dep] != workflowv1.StateCompleted {
+			// A Skipped dependency (the branch not taken) satisfies
+			// downstream tasks the same as Completed, so join tasks
+			// waiting on either branch aren't blocked forever.
+			state, _ := aggregateState(statusMap, dep)
+			if state != workflowv1.StateCompleted && state != workflowv1.StateSkipped {
 				allDepsCompleted = false
 				break
 			}
 		}
+		if !allDepsCompleted {
+			continue
+		}
 
-		if allDepsCompleted {
-			candidates = append(candidates, task)
+		if task.Type == workflowv1.TaskTypeSysBatch {
+			clones, err := s.fanOutSysBatch(ctx, task)
+			if err != nil {
+				return nil, 0, err
+			}
+			candidates = append(candidates, clones...)
+			continue
 		}
+		candidates = append(candidates, task)
 	}
+	return candidates, activeCount, nil
+}
+
+// Schedule determines which tasks of dag should be executed next for run.
+// Task progress is read from run.Status rather than dag.Status so concurrent
+// runs of the same Dag are scheduled independently.
+func (s *DefaultScheduler) Schedule(ctx context.Context, dag *workflowv1.Dag, run *workflowv1.DagRun) ([]workflowv1.TaskSpec, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates, activeCount, err := s.readyCandidates(ctx, dag, run)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks Preempt pulled out of StateRunning go first, ahead of anything
+	// newly ready, so a preemption doesn't just lose its slot to a fresh
+	// same-bucket FIFO peer on the very next Schedule call.
+	var requeuedSpecs []workflowv1.TaskSpec
+	for _, requeued := range s.preempted.DrainDag(dag.Name) {
+		if spec, ok := taskSpecByName(dag, requeued.TaskName); ok {
+			requeuedSpecs = append(requeuedSpecs, spec)
+		}
+	}
+	candidates = append(requeuedSpecs, candidates...)
 
 	// Apply scheduling policy
 	s.sortByPolicy(candidates, dag)
@@ -98,6 +189,15 @@ func (s *DefaultScheduler) Schedule(ctx context.Context, dag *workflowv1.Dag) ([
 		return nil, nil
 	}
 
+	switch s.config.Policy {
+	case PolicyPriority:
+		return s.applyBucketCaps(candidates, dag, run, availableSlots), nil
+	case PolicyFairShare:
+		perDagCap := int(s.config.MaxActiveTasksPerDag)
+		perDagRemaining := perDagCap - s.activeTasksPerDAG[dag.Name]
+		return s.applyFairShare(dag, candidates, &availableSlots, perDagCap, &perDagRemaining), nil
+	}
+
 	if len(candidates) > availableSlots {
 		candidates = candidates[:availableSlots]
 	}
@@ -105,28 +205,159 @@ func (s *DefaultScheduler) Schedule(ctx context.Context, dag *workflowv1.Dag) ([
 	return candidates, nil
 }
 
-// CanSchedule checks if a task can be scheduled
+// ScheduleFairShare runs one weighted deficit round-robin round across
+// dags, sharing a single global MaxActiveTasks budget between them in
+// proportion to their FairShareConfig weight rather than scheduling each
+// Dag's tasks independently the way Schedule does. Dags are visited in a
+// stable (name) order each round so deficit accrual doesn't depend on the
+// order dags/runs happen to be passed in.
+func (s *DefaultScheduler) ScheduleFairShare(ctx context.Context, dags []*workflowv1.Dag, runs map[string]*workflowv1.DagRun) (map[string][]workflowv1.TaskSpec, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ordered := make([]*workflowv1.Dag, len(dags))
+	copy(ordered, dags)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Name < ordered[j].Name })
+
+	globalRemaining := int(s.config.MaxActiveTasks) - s.totalActiveTasks
+	perDagCap := int(s.config.MaxActiveTasksPerDag)
+
+	result := make(map[string][]workflowv1.TaskSpec, len(ordered))
+	for _, dag := range ordered {
+		run, ok := runs[dag.Name]
+		if !ok {
+			continue
+		}
+
+		candidates, _, err := s.readyCandidates(ctx, dag, run)
+		if err != nil {
+			return nil, err
+		}
+		perDagRemaining := perDagCap - s.activeTasksPerDAG[dag.Name]
+		result[dag.Name] = s.applyFairShare(dag, candidates, &globalRemaining, perDagCap, &perDagRemaining)
+	}
+
+	return result, nil
+}
+
+// applyBucketCaps drains candidates (already sorted into bucketOrder by
+// sortByPolicy) in priority order, subject to availableSlots, each bucket's
+// own cap (SchedulerConfig.BucketCaps, see bucketCap), and
+// MaxActiveTasksPerDag. A candidate that doesn't fit this round is simply
+// left off the returned slice — it stays a candidate on the next Schedule
+// call once something frees a slot, the same backpressure Schedule already
+// applies via availableSlots today.
+func (s *DefaultScheduler) applyBucketCaps(candidates []workflowv1.TaskSpec, dag *workflowv1.Dag, run *workflowv1.DagRun, availableSlots int) []workflowv1.TaskSpec {
+	activeInBucket := make(map[workflowv1.TaskPriority]int)
+	for _, ts := range run.Status.TaskStatuses {
+		if ts.State != workflowv1.StateRunning && ts.State != workflowv1.StatePending {
+			continue
+		}
+		if spec, ok := taskSpecByName(dag, ts.Name); ok {
+			activeInBucket[bucketOf(spec.Priority)]++
+		}
+	}
+
+	perDagCap := int(s.config.MaxActiveTasksPerDag)
+	perDagRemaining := perDagCap - s.activeTasksPerDAG[dag.Name]
+
+	var selected []workflowv1.TaskSpec
+	remaining := availableSlots
+	for _, bucket := range bucketOrder {
+		bucketRemaining := int(bucketCap(s.config.BucketCaps, bucket)) - activeInBucket[bucket]
+		for _, task := range candidates {
+			if bucketOf(task.Priority) != bucket {
+				continue
+			}
+			if remaining <= 0 {
+				return selected
+			}
+			if perDagCap > 0 && perDagRemaining <= 0 {
+				return selected
+			}
+			if bucketRemaining <= 0 {
+				break
+			}
+
+			selected = append(selected, task)
+			remaining--
+			bucketRemaining--
+			if perDagCap > 0 {
+				perDagRemaining--
+			}
+		}
+	}
+	return selected
+}
+
+// CanSchedule reports whether task can be scheduled right now given
+// MaxActiveTasks, MaxActiveTasksPerDag and (under PolicyPriority) its
+// bucket's cap, returning ErrTaskQueueFull for whichever limit blocks it.
 func (s *DefaultScheduler) CanSchedule(ctx context.Context, task *TaskInfo) (bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.totalActiveTasks >= int(s.config.MaxActiveTasks) {
-		return false, nil
+		return false, ErrTaskQueueFull
+	}
+
+	if s.config.MaxActiveTasksPerDag > 0 && s.activeTasksPerDAG[task.DagName] >= int(s.config.MaxActiveTasksPerDag) {
+		return false, ErrTaskQueueFull
 	}
+
+	if s.config.Policy == PolicyPriority {
+		bucket := bucketOf(task.Priority)
+		if int32(s.activeTasksByBucket[bucket]) >= bucketCap(s.config.BucketCaps, bucket) {
+			return false, ErrTaskQueueFull
+		}
+	}
+
 	return true, nil
 }
 
-// NotifyTaskStarted updates internal state when a task starts
-func (s *DefaultScheduler) NotifyTaskStarted(dagName, taskName string) {
+// NotifyTaskStarted updates internal state when a task starts, so later
+// CanSchedule/Preempt calls see it as occupying a slot in its priority
+// bucket. When s.config.History is set, it also records a new
+// history.TaskAttempt for executionID/taskName/taskType, one per call (a
+// retried task calling NotifyTaskStarted again gets its own Attempt number).
+func (s *DefaultScheduler) NotifyTaskStarted(ctx context.Context, dagName, taskName string, priority workflowv1.TaskPriority, executionID string, taskType workflowv1.TaskType) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.activeTasksPerDAG[dagName]++
 	s.totalActiveTasks++
+	s.activeTasksByBucket[bucketOf(priority)]++
+	s.runningTasks[runningKey(dagName, taskName)] = &TaskInfo{
+		DagName:  dagName,
+		TaskName: taskName,
+		Priority: priority,
+	}
+
+	if s.config.History == nil {
+		return
+	}
+	key := runningKey(dagName, taskName)
+	s.attemptSeq[key]++
+	attempt := history.TaskAttempt{
+		ID:           taskAttemptID(executionID, taskName, s.attemptSeq[key]),
+		ExecutionID:  executionID,
+		TaskName:     taskName,
+		ExecutorType: string(taskType),
+		Status:       workflowv1.StateRunning,
+		StartTime:    time.Now(),
+		Attempt:      s.attemptSeq[key],
+	}
+	s.attemptStart[key] = attempt
+	if err := s.config.History.RecordTaskAttempt(ctx, &attempt); err != nil {
+		log.Error(err, "failed to record task attempt start", "dag", dagName, "task", taskName)
+	}
 }
 
-// NotifyTaskCompleted updates internal state when a task completes
-func (s *DefaultScheduler) NotifyTaskCompleted(dagName, taskName string) {
+// NotifyTaskCompleted updates internal state when a task completes. When
+// s.config.History is set, it also updates the most recent history.
+// TaskAttempt recorded for dagName/taskName (via NotifyTaskStarted) to
+// state, with taskErr's message if non-nil.
+func (s *DefaultScheduler) NotifyTaskCompleted(ctx context.Context, dagName, taskName string, priority workflowv1.TaskPriority, executionID string, state workflowv1.TaskState, taskErr error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -136,10 +367,86 @@ func (s *DefaultScheduler) NotifyTaskCompleted(dagName, taskName string) {
 	if s.totalActiveTasks > 0 {
 		s.totalActiveTasks--
 	}
+	if s.activeTasksByBucket[bucketOf(priority)] > 0 {
+		s.activeTasksByBucket[bucketOf(priority)]--
+	}
 
 	if s.activeTasksPerDAG[dagName] == 0 {
 		delete(s.activeTasksPerDAG, dagName)
 	}
+	delete(s.runningTasks, runningKey(dagName, taskName))
+
+	if s.config.History == nil {
+		return
+	}
+	key := runningKey(dagName, taskName)
+	attempt, ok := s.attemptStart[key]
+	if !ok {
+		return
+	}
+	delete(s.attemptStart, key)
+
+	attempt.Status = state
+	now := time.Now()
+	attempt.EndTime = &now
+	if taskErr != nil {
+		attempt.Error = taskErr.Error()
+	}
+	if err := s.config.History.RecordTaskAttempt(ctx, &attempt); err != nil {
+		log.Error(err, "failed to record task attempt completion", "dag", dagName, "task", taskName)
+	}
+}
+
+// taskAttemptID builds a history.TaskAttempt.ID stable across the start/
+// completion RecordTaskAttempt calls for the same attempt.
+func taskAttemptID(executionID, taskName string, attempt int32) string {
+	return fmt.Sprintf("%s/%s/%d", executionID, taskName, attempt)
+}
+
+// Preempt looks for dag's lowest-priority task currently tracked as running
+// (via NotifyTaskStarted) and, if one exists, frees its slot as though it
+// had completed and offers it back to the next Schedule call ahead of its
+// same-bucket FIFO peers (see the preempted field). The caller is
+// responsible for actually cancelling the returned task's Pod/run; Preempt
+// only updates the scheduler's own bookkeeping. Returns nil, nil if dag has
+// no running task to preempt.
+func (s *DefaultScheduler) Preempt(ctx context.Context, dag *workflowv1.Dag) (*TaskInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var victim *TaskInfo
+	for _, t := range s.runningTasks {
+		if t.DagName != dag.Name {
+			continue
+		}
+		if victim == nil || bucketRank(t.Priority) > bucketRank(victim.Priority) {
+			victim = t
+		}
+	}
+	if victim == nil {
+		return nil, nil
+	}
+
+	delete(s.runningTasks, runningKey(victim.DagName, victim.TaskName))
+	if s.activeTasksPerDAG[victim.DagName] > 0 {
+		s.activeTasksPerDAG[victim.DagName]--
+	}
+	if s.activeTasksPerDAG[victim.DagName] == 0 {
+		delete(s.activeTasksPerDAG, victim.DagName)
+	}
+	if s.totalActiveTasks > 0 {
+		s.totalActiveTasks--
+	}
+	if s.activeTasksByBucket[bucketOf(victim.Priority)] > 0 {
+		s.activeTasksByBucket[bucketOf(victim.Priority)]--
+	}
+
+	// Best-effort: if the preempted queue is itself full, the victim's slot
+	// is still freed, it just won't be fast-tracked back in ahead of fresh
+	// candidates.
+	_ = s.preempted.EnqueueFront(victim)
+
+	return victim, nil
 }
 
 // sortByPolicy sorts candidates based on the configured policy
@@ -148,14 +455,69 @@ func (s *DefaultScheduler) sortByPolicy(candidates []workflowv1.TaskSpec, dag *w
 	case PolicyFIFO:
 		return
 	case PolicyPriority:
-		// TODO: Sort by priority when TaskSpec has Priority field
-		return
+		// Stable so same-bucket tasks keep their original (FIFO) order;
+		// applyBucketCaps then drains bucket-by-bucket in this order.
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return bucketRank(candidates[i].Priority) < bucketRank(candidates[j].Priority)
+		})
 	case PolicyFairShare:
-		// TODO: Implement fair share across DAGs
+		// Fairness is across Dags, not within a single Dag's own
+		// candidates, so there's nothing to reorder here: applyFairShare
+		// (driven by Schedule/ScheduleFairShare) does the real work via
+		// each Dag's deficit counter.
 		return
 	}
 }
 
+// applyFairShare runs one WDRR round for dag: add weight*quantum to its
+// deficit, then take tasks from the front of candidates (cost 1 each, see
+// FairShareConfig) while the next task's cost fits the deficit and both
+// *globalRemaining and (when perDagCap > 0) *perDagRemaining still allow
+// it, decrementing all three as it goes. Unspent deficit carries over to
+// the next round; it resets to 0 when candidates is empty so a Dag with
+// nothing to run doesn't accrue unbounded credit.
+func (s *DefaultScheduler) applyFairShare(dag *workflowv1.Dag, candidates []workflowv1.TaskSpec, globalRemaining *int, perDagCap int, perDagRemaining *int) []workflowv1.TaskSpec {
+	if len(candidates) == 0 {
+		s.deficits[dag.Name] = 0
+		return nil
+	}
+
+	quantum := s.config.FairShare.Quantum
+	if quantum <= 0 {
+		quantum = 1
+	}
+	weight := s.config.FairShare.DefaultWeight
+	if weight <= 0 {
+		weight = 1
+	}
+	if w, ok := s.config.FairShare.Weights[dag.Name]; ok && w > 0 {
+		weight = w
+	}
+	s.deficits[dag.Name] += weight * quantum
+
+	const cost = int32(1)
+	var selected []workflowv1.TaskSpec
+	for _, task := range candidates {
+		if *globalRemaining <= 0 {
+			break
+		}
+		if perDagCap > 0 && *perDagRemaining <= 0 {
+			break
+		}
+		if s.deficits[dag.Name] < cost {
+			break
+		}
+
+		selected = append(selected, task)
+		s.deficits[dag.Name] -= cost
+		*globalRemaining--
+		if perDagCap > 0 {
+			*perDagRemaining--
+		}
+	}
+	return selected
+}
+
 // GetActiveTaskCount returns the number of currently active tasks
 func (s *DefaultScheduler) GetActiveTaskCount() int {
 	s.mu.RLock()
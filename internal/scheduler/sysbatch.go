@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/executor"
+)
+
+// sysBatchSeparator joins a TaskTypeSysBatch task's logical name to the node
+// it was fanned out onto, e.g. "cleanup--node-1". Schedule emits one
+// TaskSpec clone per eligible node under this name; dependency and
+// completion checks against the logical name ("cleanup") are resolved by
+// aggregateState, since no single TaskStatus carries that name once it's
+// fanned out.
+const sysBatchSeparator = "--"
+
+// sysBatchInstanceName names one per-node clone of a TaskTypeSysBatch task.
+func sysBatchInstanceName(taskName string, node executor.NodeID) string {
+	return taskName + sysBatchSeparator + string(node)
+}
+
+// aggregateState resolves name's effective TaskState against statuses: a
+// name matching a status directly uses that state unchanged. A SysBatch
+// logical name has no status of its own, so its state is aggregated across
+// every "name--node" instance instead: Failed if any instance failed,
+// Completed only once every instance is Completed or Skipped, Running
+// otherwise. The second return is false if name matches nothing at all.
+func aggregateState(statuses map[string]workflowv1.TaskState, name string) (workflowv1.TaskState, bool) {
+	if state, ok := statuses[name]; ok {
+		return state, true
+	}
+
+	prefix := name + sysBatchSeparator
+	found, anyFailed, allTerminal := false, false, true
+	for statusName, state := range statuses {
+		if !strings.HasPrefix(statusName, prefix) {
+			continue
+		}
+		found = true
+		switch state {
+		case workflowv1.StateFailed:
+			anyFailed = true
+		case workflowv1.StateCompleted, workflowv1.StateSkipped:
+		default:
+			allTerminal = false
+		}
+	}
+	if !found {
+		return "", false
+	}
+	if anyFailed {
+		return workflowv1.StateFailed, true
+	}
+	if allTerminal {
+		return workflowv1.StateCompleted, true
+	}
+	return workflowv1.StateRunning, true
+}
+
+// fanOutSysBatch expands a TaskTypeSysBatch candidate into one TaskSpec
+// clone per node the registered executor reports via Instances, naming each
+// clone via sysBatchInstanceName and pinning it to that node through a
+// "kubernetes.io/hostname" entry in the clone's own NodeSelector copy.
+// Instances reports node names only, not their labels, so the only
+// NodeSelector key honored here is
+// "kubernetes.io/hostname"; every other key is left for the executor to
+// enforce when it actually places the clone's Pod (see pod.Executor.buildPod,
+// which sets Spec.NodeSelector from the clone's TaskSpec.NodeSelector).
+// Returns nil (no error) if no Registry/executor/nodes are available yet,
+// leaving the task simply un-scheduled until they are.
+func (s *DefaultScheduler) fanOutSysBatch(ctx context.Context, task workflowv1.TaskSpec) ([]workflowv1.TaskSpec, error) {
+	if s.config.Registry == nil {
+		return nil, nil
+	}
+	exec, err := s.config.Registry.Get(task.Type)
+	if err != nil {
+		return nil, nil
+	}
+
+	ids, err := exec.Instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wantHost, pinned := task.NodeSelector["kubernetes.io/hostname"]
+
+	clones := make([]workflowv1.TaskSpec, 0, len(ids))
+	for _, id := range ids {
+		if pinned && string(id) != wantHost {
+			continue
+		}
+		clone := task
+		clone.Name = sysBatchInstanceName(task.Name, id)
+		clone.NodeSelector = make(map[string]string, len(task.NodeSelector)+1)
+		for k, v := range task.NodeSelector {
+			clone.NodeSelector[k] = v
+		}
+		clone.NodeSelector["kubernetes.io/hostname"] = string(id)
+		clones = append(clones, clone)
+	}
+	return clones, nil
+}
@@ -0,0 +1,419 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+// FireFunc is called by a Trigger whenever it decides a new DagRun should be
+// created for dagName. params become DagRunSpec.Parameters.
+type FireFunc func(trigger workflowv1.TriggerType, dagName string, params map[string]string)
+
+// Trigger watches for a condition that should create a new DagRun for one
+// or more Dags, and calls the configured FireFunc when it fires. This splits
+// "which Dags to run now" from Scheduler's "how to schedule the tasks inside
+// a run".
+type Trigger interface {
+	// Name identifies the trigger source (e.g. "manual", "cron", "event").
+	Name() string
+
+	// Start begins watching for fire conditions, invoking fire for each one.
+	// Blocks until ctx is cancelled or Stop is called.
+	Start(ctx context.Context, fire FireFunc) error
+
+	// Stop asks a running Start call to return.
+	Stop()
+}
+
+// ManualTrigger fires only when Fire is called directly (e.g. from a CLI
+// command or API handler submitting a DagRun by hand).
+type ManualTrigger struct {
+	stopCh chan struct{}
+	once   sync.Once
+	fire   FireFunc
+}
+
+// NewManualTrigger creates a ManualTrigger.
+func NewManualTrigger() *ManualTrigger {
+	return &ManualTrigger{stopCh: make(chan struct{})}
+}
+
+func (t *ManualTrigger) Name() string { return "manual" }
+
+// Start blocks until ctx is cancelled or Stop is called; Fire may be invoked
+// from another goroutine at any time in between.
+func (t *ManualTrigger) Start(ctx context.Context, fire FireFunc) error {
+	t.fire = fire
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.stopCh:
+		return nil
+	}
+}
+
+func (t *ManualTrigger) Stop() {
+	t.once.Do(func() { close(t.stopCh) })
+}
+
+// Fire manually triggers dagName with the given parameters.
+func (t *ManualTrigger) Fire(dagName string, params map[string]string) {
+	if t.fire != nil {
+		t.fire(workflowv1.TriggerManual, dagName, params)
+	}
+}
+
+// EventTrigger fires when an external event (e.g. a store.EventStore
+// subscription elsewhere in the process) calls Notify.
+type EventTrigger struct {
+	stopCh chan struct{}
+	once   sync.Once
+	fire   FireFunc
+}
+
+// NewEventTrigger creates an EventTrigger.
+func NewEventTrigger() *EventTrigger {
+	return &EventTrigger{stopCh: make(chan struct{})}
+}
+
+func (t *EventTrigger) Name() string { return "event" }
+
+func (t *EventTrigger) Start(ctx context.Context, fire FireFunc) error {
+	t.fire = fire
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.stopCh:
+		return nil
+	}
+}
+
+func (t *EventTrigger) Stop() {
+	t.once.Do(func() { close(t.stopCh) })
+}
+
+// Notify fires dagName in response to an external event.
+func (t *EventTrigger) Notify(dagName string, params map[string]string) {
+	if t.fire != nil {
+		t.fire(workflowv1.TriggerEvent, dagName, params)
+	}
+}
+
+// pendingFire pairs a Dag with a next scheduled time. CronTrigger keeps a
+// pending map of these but never fills it; ListPending reads next times
+// from the cron wheel's entries directly.
+type pendingFire struct {
+	dagName string
+	next    time.Time
+}
+
+// CronTrigger maintains a cron wheel keyed by Dag name: each Dag with a
+// DagSpec.Schedule is registered once and fires on its own cadence.
+type CronTrigger struct {
+	mu       sync.Mutex
+	schedule *cron.Cron
+	entries  map[string]cron.EntryID // dagName -> cron entry
+	pending  map[string]pendingFire
+	stopCh   chan struct{}
+	once     sync.Once
+}
+
+// NewCronTrigger creates a CronTrigger with second-less (5-field) parsing.
+func NewCronTrigger() *CronTrigger {
+	return &CronTrigger{
+		schedule: cron.New(),
+		entries:  make(map[string]cron.EntryID),
+		pending:  make(map[string]pendingFire),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func (t *CronTrigger) Name() string { return "cron" }
+
+// Register adds or replaces dag's cron entry, evaluated in
+// Schedule.Timezone (UTC when empty). Suspended or unscheduled Dags, and
+// Dags with a TriggerPolicy (left to IntervalTrigger), are removed from the
+// wheel.
+func (t *CronTrigger) Register(dag *workflowv1.Dag, fire FireFunc) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if id, ok := t.entries[dag.Name]; ok {
+		t.schedule.Remove(id)
+		delete(t.entries, dag.Name)
+		delete(t.pending, dag.Name)
+	}
+
+	sched := dag.Spec.Schedule
+	if sched == nil || sched.Suspend || sched.TriggerPolicy != nil || sched.CronExpression == "" {
+		return nil
+	}
+
+	tz := sched.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	dagName := dag.Name
+	id, err := t.schedule.AddFunc("CRON_TZ="+tz+" "+sched.CronExpression, func() {
+		fire(workflowv1.TriggerScheduled, dagName, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid cron expression %q for dag %s: %w", sched.CronExpression, dagName, err)
+	}
+	t.entries[dagName] = id
+	return nil
+}
+
+// Start begins the cron wheel. Its fire argument is not used: each entry
+// calls the FireFunc given to Register, which may be called before or after
+// Start.
+func (t *CronTrigger) Start(ctx context.Context, fire FireFunc) error {
+	t.schedule.Start()
+	defer t.schedule.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.stopCh:
+		return nil
+	}
+}
+
+func (t *CronTrigger) Stop() {
+	t.once.Do(func() { close(t.stopCh) })
+}
+
+// ListPending returns the next scheduled fire time for every registered Dag,
+// giving operators a single place to audit upcoming executions.
+func (t *CronTrigger) ListPending() map[string]time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make(map[string]time.Time, len(t.entries))
+	for dagName, id := range t.entries {
+		out[dagName] = t.schedule.Entry(id).Next
+	}
+	return out
+}
+
+// maxCatchUpRuns caps how many missed occurrences IntervalTrigger replays
+// for a single Dag under MissedRunRunAll, on top of the due fire itself (so
+// at most maxCatchUpRuns+1 back-to-back fires), so a process that was down
+// for a long time doesn't flood the scheduler with a backlog of catch-up runs.
+const maxCatchUpRuns = 5
+
+// intervalEntry tracks one Dag's recurring schedule for IntervalTrigger.
+type intervalEntry struct {
+	policy workflowv1.TriggerPolicy
+	next   time.Time
+	paused bool
+}
+
+// IntervalTrigger fires Dags on a fixed-duration cadence (DagSpec.Schedule.
+// TriggerPolicy) rather than a cron expression, optionally pinned to a
+// weekday and time-of-day offset. It is a sibling of CronTrigger: both
+// implement Trigger and are driven by the same FireFunc, so a Dag can use
+// whichever fits (DagSpec.Schedule.CronExpression or .TriggerPolicy, not
+// both).
+type IntervalTrigger struct {
+	mu      sync.Mutex
+	entries map[string]*intervalEntry
+	stopCh  chan struct{}
+	once    sync.Once
+
+	// tick is the polling granularity; defaults to 1s via NewIntervalTrigger.
+	tick time.Duration
+}
+
+// NewIntervalTrigger creates an IntervalTrigger that polls once per second.
+func NewIntervalTrigger() *IntervalTrigger {
+	return &IntervalTrigger{
+		entries: make(map[string]*intervalEntry),
+		stopCh:  make(chan struct{}),
+		tick:    time.Second,
+	}
+}
+
+func (t *IntervalTrigger) Name() string { return "interval" }
+
+// Register adds or replaces dag's recurring trigger policy. A Dag with no
+// TriggerPolicy, or with Suspend set, is removed from the schedule.
+func (t *IntervalTrigger) Register(dag *workflowv1.Dag, fire FireFunc) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sched := dag.Spec.Schedule
+	if sched == nil || sched.TriggerPolicy == nil || sched.Suspend {
+		delete(t.entries, dag.Name)
+		return nil
+	}
+
+	policy := *sched.TriggerPolicy
+	if policy.Duration.Duration <= 0 {
+		return fmt.Errorf("invalid trigger policy for dag %s: duration must be positive", dag.Name)
+	}
+
+	t.entries[dag.Name] = &intervalEntry{
+		policy: policy,
+		next:   alignNext(policy, time.Now().Add(policy.Duration.Duration)),
+	}
+	return nil
+}
+
+// Start polls every t.tick, firing any entry whose next time has passed.
+// Blocks until ctx is cancelled or Stop is called.
+func (t *IntervalTrigger) Start(ctx context.Context, fire FireFunc) error {
+	ticker := time.NewTicker(t.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.stopCh:
+			return nil
+		case now := <-ticker.C:
+			t.fireDue(now, fire)
+		}
+	}
+}
+
+func (t *IntervalTrigger) Stop() {
+	t.once.Do(func() { close(t.stopCh) })
+}
+
+// fireDue fires every entry whose next time is at or before now, honoring
+// its MissedRunPolicy if the poller was asleep long enough (e.g. a process
+// restart) to have skipped one or more occurrences, then resyncs next to
+// the first upcoming occurrence strictly after now.
+func (t *IntervalTrigger) fireDue(now time.Time, fire FireFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for dagName, e := range t.entries {
+		if e.paused || now.Before(e.next) {
+			continue
+		}
+
+		missed := countOccurrences(e.policy, e.next, now)
+		switch e.policy.MissedRunPolicy {
+		case workflowv1.MissedRunSkip:
+			// Drop the missed occurrences entirely; just resync below. An
+			// entry that is due but missed nothing still fires.
+			if missed == 0 {
+				fire(workflowv1.TriggerScheduled, dagName, nil)
+			}
+		case workflowv1.MissedRunRunAll:
+			for i := 0; i <= missed; i++ {
+				fire(workflowv1.TriggerScheduled, dagName, nil)
+			}
+		default: // MissedRunRunOnce, or unset
+			fire(workflowv1.TriggerScheduled, dagName, nil)
+		}
+
+		next := e.next
+		for !next.After(now) {
+			next = nextFire(e.policy, next)
+		}
+		e.next = next
+	}
+}
+
+// Pause stops dagName from firing until Resume is called, without losing
+// its registration.
+func (t *IntervalTrigger) Pause(dagName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[dagName]; ok {
+		e.paused = true
+	}
+}
+
+// Resume re-arms dagName, scheduling its next occurrence one Duration from
+// now rather than replaying whatever was missed while paused.
+func (t *IntervalTrigger) Resume(dagName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[dagName]; ok {
+		e.paused = false
+		e.next = alignNext(e.policy, time.Now().Add(e.policy.Duration.Duration))
+	}
+}
+
+// ListPending returns the next scheduled fire time for every registered Dag,
+// mirroring CronTrigger.ListPending.
+func (t *IntervalTrigger) ListPending() map[string]time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make(map[string]time.Time, len(t.entries))
+	for dagName, e := range t.entries {
+		out[dagName] = e.next
+	}
+	return out
+}
+
+// nextFire returns the next occurrence after from, given policy's Duration,
+// and re-aligns it to policy's Weekday/OffsetSeconds constraints if set.
+func nextFire(policy workflowv1.TriggerPolicy, from time.Time) time.Time {
+	d := policy.Duration.Duration
+	if d <= 0 {
+		d = time.Hour
+	}
+	return alignNext(policy, from.Add(d))
+}
+
+// alignNext nudges t forward, if needed, to satisfy policy.OffsetSeconds
+// (seconds past 00:00 UTC; the next UTC day's offset when t's own day's has
+// already passed) and then policy.Weekday (ISO weekday, 1=Monday..7=Sunday;
+// 0 means no constraint).
+func alignNext(policy workflowv1.TriggerPolicy, t time.Time) time.Time {
+	if policy.OffsetSeconds > 0 {
+		u := t.UTC()
+		dayStart := time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+		aligned := dayStart.Add(time.Duration(policy.OffsetSeconds) * time.Second)
+		if aligned.Before(t) {
+			aligned = aligned.Add(24 * time.Hour)
+		}
+		t = aligned
+	}
+	if policy.Weekday > 0 {
+		for isoWeekday(t) != policy.Weekday {
+			t = t.Add(24 * time.Hour)
+		}
+	}
+	return t
+}
+
+// isoWeekday converts Go's time.Weekday (Sunday=0) to ISO-8601 (Monday=1,
+// Sunday=7), matching TriggerPolicy.Weekday's documented convention.
+func isoWeekday(t time.Time) int {
+	wd := int(t.Weekday())
+	if wd == 0 {
+		return 7
+	}
+	return wd
+}
+
+// countOccurrences returns how many full policy.Duration periods elapsed
+// between from and to, capped at maxCatchUpRuns.
+func countOccurrences(policy workflowv1.TriggerPolicy, from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	d := policy.Duration.Duration
+	if d <= 0 {
+		d = time.Hour
+	}
+	n := int(to.Sub(from) / d)
+	if n > maxCatchUpRuns {
+		n = maxCatchUpRuns
+	}
+	return n
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+func registerInterval(t *testing.T, trig *IntervalTrigger, dagName string, policy workflowv1.TriggerPolicy) {
+	t.Helper()
+	dag := &workflowv1.Dag{
+		ObjectMeta: metav1.ObjectMeta{Name: dagName},
+		Spec: workflowv1.DagSpec{
+			Schedule: &workflowv1.ScheduleSpec{TriggerPolicy: &policy},
+		},
+	}
+	if err := trig.Register(dag, func(workflowv1.TriggerType, string, map[string]string) {}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+}
+
+func TestIntervalTrigger_FireDue_FiresOnceWhenNoMiss(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration: metav1.Duration{Duration: time.Minute},
+	})
+
+	now := time.Now()
+	trig.entries["dag-a"].next = now.Add(-time.Second) // already due, not missed
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 1 {
+		t.Errorf("expected exactly 1 fire, got %d", fires)
+	}
+	if next := trig.entries["dag-a"].next; !next.After(now) {
+		t.Errorf("expected next to resync strictly after now, got %v (now=%v)", next, now)
+	}
+}
+
+func TestIntervalTrigger_FireDue_MissedRunSkip(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration:        metav1.Duration{Duration: time.Minute},
+		MissedRunPolicy: workflowv1.MissedRunSkip,
+	})
+
+	now := time.Now()
+	// 5 minutes elapsed since next was due: 5 missed occurrences.
+	trig.entries["dag-a"].next = now.Add(-5 * time.Minute)
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 0 {
+		t.Errorf("MissedRunSkip: expected 0 fires, got %d", fires)
+	}
+}
+
+func TestIntervalTrigger_FireDue_MissedRunRunOnce(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration:        metav1.Duration{Duration: time.Minute},
+		MissedRunPolicy: workflowv1.MissedRunRunOnce,
+	})
+
+	now := time.Now()
+	trig.entries["dag-a"].next = now.Add(-5 * time.Minute)
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 1 {
+		t.Errorf("MissedRunRunOnce: expected exactly 1 fire regardless of how many were missed, got %d", fires)
+	}
+}
+
+func TestIntervalTrigger_FireDue_MissedRunRunAll(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration:        metav1.Duration{Duration: time.Minute},
+		MissedRunPolicy: workflowv1.MissedRunRunAll,
+	})
+
+	now := time.Now()
+	// Exactly 4 whole periods elapsed since the due time: countOccurrences
+	// returns 4, and fireDue fires once per missed period plus the due one.
+	trig.entries["dag-a"].next = now.Add(-4 * time.Minute)
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 5 {
+		t.Errorf("MissedRunRunAll: expected 5 fires (4 missed + 1 due), got %d", fires)
+	}
+}
+
+func TestIntervalTrigger_FireDue_MissedRunRunAll_CapsAtMaxCatchUpRuns(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration:        metav1.Duration{Duration: time.Minute},
+		MissedRunPolicy: workflowv1.MissedRunRunAll,
+	})
+
+	now := time.Now()
+	// Far more than maxCatchUpRuns periods elapsed.
+	trig.entries["dag-a"].next = now.Add(-100 * time.Minute)
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != maxCatchUpRuns+1 {
+		t.Errorf("expected fires capped at maxCatchUpRuns+1 (%d), got %d", maxCatchUpRuns+1, fires)
+	}
+}
+
+func TestIntervalTrigger_FireDue_SkipsPausedEntries(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration: metav1.Duration{Duration: time.Minute},
+	})
+	trig.Pause("dag-a")
+
+	now := time.Now()
+	trig.entries["dag-a"].next = now.Add(-time.Minute)
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 0 {
+		t.Errorf("expected a paused entry not to fire, got %d fires", fires)
+	}
+}
+
+func TestIntervalTrigger_FireDue_SkipsEntriesNotYetDue(t *testing.T) {
+	trig := NewIntervalTrigger()
+	registerInterval(t, trig, "dag-a", workflowv1.TriggerPolicy{
+		Duration: metav1.Duration{Duration: time.Minute},
+	})
+
+	now := time.Now()
+	future := trig.entries["dag-a"].next // set by Register to now+Duration, still in the future
+
+	var fires int
+	trig.fireDue(now, func(workflowv1.TriggerType, string, map[string]string) { fires++ })
+
+	if fires != 0 {
+		t.Errorf("expected no fire before next is due, got %d", fires)
+	}
+	if trig.entries["dag-a"].next != future {
+		t.Errorf("expected next to be left untouched when not yet due")
+	}
+}
@@ -0,0 +1,61 @@
+// Package filesystem provides a local-disk scriptstore.Store backend,
+// intended for `kind`/dev clusters where a shared registry isn't available.
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kination/nautikus/internal/scriptstore"
+)
+
+// Store persists scripts as plain files named after their digest under
+// RootDir, shared between the SDK (writer) and the init container (reader)
+// via a hostPath/PVC mount in real deployments.
+type Store struct {
+	RootDir string
+}
+
+// New creates a filesystem Store rooted at dir, creating it if needed.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create script store dir %s: %w", dir, err)
+	}
+	return &Store{RootDir: dir}, nil
+}
+
+// Put implements scriptstore.Store.
+func (s *Store) Put(ctx context.Context, content []byte) (string, error) {
+	digest := scriptstore.Digest(content)
+	path := s.pathFor(digest)
+
+	if _, err := os.Stat(path); err == nil {
+		return s.refFor(digest), nil // already uploaded
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write script %s: %w", digest, err)
+	}
+	return s.refFor(digest), nil
+}
+
+// Get implements scriptstore.Store.
+func (s *Store) Get(ctx context.Context, storeRef string) ([]byte, error) {
+	digest := strings.TrimPrefix(storeRef, "file://")
+	content, err := os.ReadFile(s.pathFor(digest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script %s: %w", storeRef, err)
+	}
+	return content, nil
+}
+
+func (s *Store) pathFor(digest string) string {
+	return filepath.Join(s.RootDir, strings.ReplaceAll(digest, ":", "_"))
+}
+
+func (s *Store) refFor(digest string) string {
+	return "file://" + digest
+}
@@ -0,0 +1,30 @@
+// Package scriptstore provides content-addressed storage for DAG task
+// scripts, so the SDK can upload a source file once and put only a small
+// {digest, storeRef} pair in workflowv1.TaskSpec instead of inlining the
+// whole file, which otherwise bloats the DAG CR and can hit etcd's
+// per-object size limit for large SDK files.
+package scriptstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Store uploads and retrieves script content by its digest.
+type Store interface {
+	// Put uploads content, returning a storeRef the backend can later
+	// resolve back to the same bytes via Get.
+	Put(ctx context.Context, content []byte) (storeRef string, err error)
+
+	// Get retrieves content previously uploaded under storeRef.
+	Get(ctx context.Context, storeRef string) ([]byte, error)
+}
+
+// Digest returns the content-addressed hash used to key script content,
+// shared by every backend so TaskSpec.ScriptRef.Digest is comparable
+// regardless of where the content is actually stored.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,106 @@
+// Package registry provides an OCI-artifact-backed scriptstore.Store,
+// pushing script content as a single-layer artifact so it benefits from
+// whatever registry auth, caching, and garbage collection is already in
+// place for container images in the cluster.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+
+	"github.com/kination/nautikus/internal/scriptstore"
+)
+
+const mediaType = "application/vnd.nautikus.script.v1+octet-stream"
+
+// Store pushes/pulls script content as OCI artifacts under Repository,
+// tagged by digest (e.g. "registry.example.com/nautikus/scripts").
+type Store struct {
+	Repository string
+}
+
+// New creates a registry-backed Store targeting repo.
+func New(repo string) *Store {
+	return &Store{Repository: repo}
+}
+
+// Put implements scriptstore.Store.
+func (s *Store) Put(ctx context.Context, content []byte) (string, error) {
+	digest := scriptstore.Digest(content)
+	ref := s.refFor(digest)
+
+	layer := static.NewLayer(content, mediaType)
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", fmt.Errorf("failed to build artifact for script %s: %w", digest, err)
+	}
+
+	if err := crane.Push(img, ref, crane.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return "", fmt.Errorf("failed to push script %s to %s: %w", digest, ref, err)
+	}
+	return "registry://" + ref, nil
+}
+
+// Get implements scriptstore.Store.
+func (s *Store) Get(ctx context.Context, storeRef string) ([]byte, error) {
+	ref := trimRegistryScheme(storeRef)
+
+	img, err := crane.Pull(ref, crane.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull script %s: %w", storeRef, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil || len(layers) != 1 {
+		return nil, fmt.Errorf("script artifact %s has unexpected layer count", storeRef)
+	}
+
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script %s: %w", storeRef, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script %s: %w", storeRef, err)
+	}
+	return content, nil
+}
+
+func (s *Store) refFor(digest string) string {
+	tag := digestTag(digest)
+	r, err := name.ParseReference(fmt.Sprintf("%s:%s", s.Repository, tag))
+	if err != nil {
+		return fmt.Sprintf("%s:%s", s.Repository, tag)
+	}
+	return r.Name()
+}
+
+// digestTag turns "sha256:abcd..." into a valid OCI tag ("sha256-abcd...").
+func digestTag(digest string) string {
+	out := make([]byte, len(digest))
+	copy(out, digest)
+	for i, c := range out {
+		if c == ':' {
+			out[i] = '-'
+		}
+	}
+	return string(out)
+}
+
+func trimRegistryScheme(storeRef string) string {
+	const prefix = "registry://"
+	if len(storeRef) > len(prefix) && storeRef[:len(prefix)] == prefix {
+		return storeRef[len(prefix):]
+	}
+	return storeRef
+}
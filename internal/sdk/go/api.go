@@ -78,15 +78,21 @@ func (b *DAGBuilder) AddParallel(afterTask string, tasks ...Task) *DAGBuilder {
 	return b
 }
 
-// AddBranch adds conditional branching (like Airflow's BranchPythonOperator)
-// The condition task determines which branch to execute
-func (b *DAGBuilder) AddBranch(conditionTaskName string, conditionFn func() string, branches map[string][]Task) *DAGBuilder {
+// AddBranch adds conditional branching (like Airflow's BranchPythonOperator).
+// With no rules, the branch is decided by conditionFn, which runs inside the
+// branch task's own Pod (processing.ExecuteTask) and so cannot see upstream
+// tasks' states or outputs. Passing rules instead makes the decision
+// data-dependent: the Runner evaluates them once conditionTaskName's task
+// reaches StateCompleted, against that run's actual task states/outputs
+// (see workflowv1.BranchRule), and conditionFn is ignored.
+func (b *DAGBuilder) AddBranch(conditionTaskName string, conditionFn func() string, branches map[string][]Task, rules ...processing.BranchRule) *DAGBuilder {
 	// Add the condition task that returns which branch to take
 	b.tasks = append(b.tasks, processing.TaskDef{
-		Name:           conditionTaskName,
-		BranchFn:       conditionFn,
-		TaskType:       processing.TaskTypeBranch,
-		BranchTargets:  getBranchNames(branches),
+		Name:          conditionTaskName,
+		BranchFn:      conditionFn,
+		TaskType:      processing.TaskTypeBranch,
+		BranchTargets: getBranchNames(branches),
+		BranchRules:   rules,
 	})
 
 	// Add all branch tasks with skip conditions
@@ -114,6 +120,39 @@ func (b *DAGBuilder) AddBranch(conditionTaskName string, conditionFn func() stri
 	return b
 }
 
+// SkipBranch removes branchName's tasks from the generated manifest
+// entirely, decided at manifest-generation time. It's a quick way to
+// disable one arm of an AddBranch call without editing the branches map
+// itself, e.g. behind a build-time feature flag.
+func (b *DAGBuilder) SkipBranch(branchName string) *DAGBuilder {
+	kept := b.tasks[:0]
+	for _, t := range b.tasks {
+		if t.BranchCondition == branchName {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	b.tasks = kept
+	return b
+}
+
+// AddCustomTask adds a task whose execution is delegated entirely to the
+// ExternalResourceConnector registered for apiVersion/kind, Tekton "Run"
+// style. spec is opaque and passed through to the connector unmodified.
+func (b *DAGBuilder) AddCustomTask(name, apiVersion, kind string, spec []byte, deps ...string) *DAGBuilder {
+	b.tasks = append(b.tasks, processing.TaskDef{
+		Name:         name,
+		Dependencies: deps,
+		TaskType:     processing.TaskTypeCustom,
+		CustomRef: &processing.CustomTaskRef{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Spec:       spec,
+		},
+	})
+	return b
+}
+
 // AddJoin adds a join task that waits for any of the specified tasks
 func (b *DAGBuilder) AddJoin(name string, fn func(), waitFor ...string) *DAGBuilder {
 	b.tasks = append(b.tasks, processing.TaskDef{
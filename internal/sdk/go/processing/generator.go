@@ -1,26 +1,55 @@
 package processing
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/scriptstore"
+	"github.com/kination/nautikus/internal/scriptstore/filesystem"
+	"github.com/kination/nautikus/internal/scriptstore/registry"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GenerateManifest creates the DAG JSON manifest from task definitions
 func GenerateManifest(dagName string, tasks []TaskDef) {
 	scriptContent := readCallerSource()
+	scriptRef := uploadScript(scriptContent)
 
 	taskSpecs := make([]workflowv1.TaskSpec, 0, len(tasks))
 
 	for _, task := range tasks {
+		if task.TaskType == TaskTypeCustom {
+			taskSpecs = append(taskSpecs, workflowv1.TaskSpec{
+				Name:         task.Name,
+				Type:         workflowv1.TaskTypeCustom,
+				Dependencies: task.Dependencies,
+				CustomRef: &workflowv1.CustomTaskRef{
+					APIVersion: task.CustomRef.APIVersion,
+					Kind:       task.CustomRef.Kind,
+					Spec:       task.CustomRef.Spec,
+				},
+			})
+			continue
+		}
+
+		if task.TaskType == TaskTypeSysBatch {
+			taskSpecs = append(taskSpecs, workflowv1.TaskSpec{
+				Name:         task.Name,
+				Type:         workflowv1.TaskTypeSysBatch,
+				Dependencies: task.Dependencies,
+				NodeSelector: task.NodeSelector,
+			})
+			continue
+		}
+
 		spec := workflowv1.TaskSpec{
 			Name:         task.Name,
 			Type:         workflowv1.TaskTypeGo,
-			Script:       scriptContent,
+			ScriptRef:    scriptRef,
 			Dependencies: task.Dependencies,
 			Env: map[string]string{
 				"NAUTIKUS_TASK_NAME": task.Name,
@@ -40,6 +69,15 @@ func GenerateManifest(dagName string, tasks []TaskDef) {
 			if len(task.BranchTargets) > 0 {
 				spec.Env["NAUTIKUS_BRANCH_TARGETS"] = joinStrings(task.BranchTargets, ",")
 			}
+			// Runtime BranchRules take over from BranchFn: the Runner
+			// evaluates them once this task reaches StateCompleted, against
+			// the run's task states/outputs.
+			for _, rule := range task.BranchRules {
+				spec.BranchRules = append(spec.BranchRules, workflowv1.BranchRule{
+					Expression:   rule.Expression,
+					TargetBranch: rule.TargetBranch,
+				})
+			}
 		}
 
 		// Mark join tasks
@@ -72,6 +110,43 @@ func GenerateManifest(dagName string, tasks []TaskDef) {
 	fmt.Println(string(output))
 }
 
+// uploadScript pushes content to the configured scriptstore backend and
+// returns the resulting ScriptRef, so the generated manifest only carries a
+// {digest, storeRef} pair instead of inlining the whole source file.
+func uploadScript(content string) *workflowv1.ScriptRef {
+	store, err := scriptStoreBackend()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error selecting script store backend: %v\n", err)
+		os.Exit(1)
+	}
+
+	storeRef, err := store.Put(context.Background(), []byte(content))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error uploading script: %v\n", err)
+		os.Exit(1)
+	}
+
+	return &workflowv1.ScriptRef{
+		Digest:   scriptstore.Digest([]byte(content)),
+		StoreRef: storeRef,
+	}
+}
+
+// scriptStoreBackend picks the scriptstore.Store the generated manifest will
+// reference, mirroring the scheme selection cmd/script-fetch uses at fetch
+// time. Defaults to the local filesystem store for `kind`/dev use.
+func scriptStoreBackend() (scriptstore.Store, error) {
+	if repo := os.Getenv("NAUTIKUS_SCRIPT_STORE_REPO"); repo != "" {
+		return registry.New(repo), nil
+	}
+
+	dir := os.Getenv("NAUTIKUS_SCRIPT_STORE_DIR")
+	if dir == "" {
+		dir = "/var/lib/nautikus/scripts"
+	}
+	return filesystem.New(dir)
+}
+
 func readCallerSource() string {
 	// Walk up the stack to find the original caller (user's dag file)
 	for i := 1; i < 10; i++ {
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Factory builds a Store for a given configuration. Backends register their
+// Factory via Register (typically from an init() in their package, mirroring
+// how database/sql drivers register themselves), so this package never needs
+// to import its own implementations and callers only pull in the backends
+// they actually use via blank import.
+type Factory func(ctx context.Context, cfg StoreConfig) (Store, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[StoreType]Factory)
+)
+
+// Register makes a Store backend available under the given StoreType. It
+// panics if called twice for the same type, mirroring sql.Register.
+func Register(t StoreType, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if _, exists := factories[t]; exists {
+		panic(fmt.Sprintf("store: Register called twice for type %q", t))
+	}
+	factories[t] = factory
+}
+
+// New creates a Store for cfg.Type, returning an error if no backend has
+// been registered for that type (usually because its package was never
+// blank-imported).
+func New(ctx context.Context, cfg StoreConfig) (Store, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[cfg.Type]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("store: no backend registered for type %q (forgot to import it?)", cfg.Type)
+	}
+	return factory(ctx, cfg)
+}
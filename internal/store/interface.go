@@ -6,7 +6,7 @@ import (
 	"context"
 	"time"
 
-	workflowv1 "github.com/kination/nautikus/api/v1"
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
 )
 
 // Store defines the interface for DAG and task persistence.
@@ -18,10 +18,13 @@ type Store interface {
 	ListDAGs(ctx context.Context, namespace string, opts ListOptions) ([]*workflowv1.Dag, error)
 	DeleteDAG(ctx context.Context, namespace, name string) error
 
-	// Task status operations
-	SaveTaskStatus(ctx context.Context, dagNamespace, dagName, taskName string, status *workflowv1.TaskStatus) error
-	GetTaskStatus(ctx context.Context, dagNamespace, dagName, taskName string) (*workflowv1.TaskStatus, error)
-	ListTaskStatuses(ctx context.Context, dagNamespace, dagName string) ([]workflowv1.TaskStatus, error)
+	// Task status operations. runID scopes statuses to one execution of the
+	// Dag (see runner.runID) so concurrent DagRuns of the same Dag don't
+	// overwrite each other's TaskStatus entries; callers with no concept of
+	// a run (e.g. a reconciler with no DagRun CR) pass "".
+	SaveTaskStatus(ctx context.Context, dagNamespace, dagName, runID, taskName string, status *workflowv1.TaskStatus) error
+	GetTaskStatus(ctx context.Context, dagNamespace, dagName, runID, taskName string) (*workflowv1.TaskStatus, error)
+	ListTaskStatuses(ctx context.Context, dagNamespace, dagName, runID string) ([]workflowv1.TaskStatus, error)
 
 	// History operations (for completed DAG runs)
 	SaveDAGRun(ctx context.Context, run *DAGRun) error
@@ -165,6 +168,9 @@ const (
 	EventTypeTaskCompleted EventType = "task.completed"
 	// EventTypeTaskFailed is emitted when a task fails
 	EventTypeTaskFailed EventType = "task.failed"
+	// EventTypeTaskSkipped is emitted when a task is skipped because it
+	// belongs to a branch a BranchRule didn't select
+	EventTypeTaskSkipped EventType = "task.skipped"
 )
 
 // EventFilter defines criteria for filtering events
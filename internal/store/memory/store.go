@@ -0,0 +1,227 @@
+// Package memory provides an in-memory implementation of store.Store,
+// primarily intended for tests and single-replica dev deployments.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+	"github.com/kination/nautikus/internal/store"
+)
+
+func init() {
+	store.Register(store.StoreTypeMemory, func(ctx context.Context, cfg store.StoreConfig) (store.Store, error) {
+		return New(), nil
+	})
+}
+
+// Store implements store.Store backed by in-process maps. It is not
+// persisted across restarts and should not be used as the source of truth
+// for production history, but satisfies the full interface so callers can
+// depend on store.Store without caring about the backend.
+type Store struct {
+	mu sync.RWMutex
+
+	dags         map[string]*workflowv1.Dag
+	taskStatuses map[string]map[string]*workflowv1.TaskStatus
+	dagRuns      map[string]*store.DAGRun
+}
+
+// New creates a new in-memory Store.
+func New() *Store {
+	return &Store{
+		dags:         make(map[string]*workflowv1.Dag),
+		taskStatuses: make(map[string]map[string]*workflowv1.TaskStatus),
+		dagRuns:      make(map[string]*store.DAGRun),
+	}
+}
+
+func dagKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// taskStatusKey additionally scopes a dagKey by runID, so concurrent runs of
+// the same Dag don't share one taskStatuses map.
+func taskStatusKey(namespace, name, runID string) string {
+	return dagKey(namespace, name) + "/" + runID
+}
+
+// SaveDAG implements store.Store.
+func (s *Store) SaveDAG(ctx context.Context, dag *workflowv1.Dag) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dags[dagKey(dag.Namespace, dag.Name)] = dag.DeepCopy()
+	return nil
+}
+
+// GetDAG implements store.Store.
+func (s *Store) GetDAG(ctx context.Context, namespace, name string) (*workflowv1.Dag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dag, ok := s.dags[dagKey(namespace, name)]
+	if !ok {
+		return nil, fmt.Errorf("dag %s/%s not found", namespace, name)
+	}
+	return dag.DeepCopy(), nil
+}
+
+// ListDAGs implements store.Store.
+func (s *Store) ListDAGs(ctx context.Context, namespace string, opts store.ListOptions) ([]*workflowv1.Dag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*workflowv1.Dag
+	for _, dag := range s.dags {
+		if namespace != "" && dag.Namespace != namespace {
+			continue
+		}
+		if opts.State != "" && dag.Status.State != opts.State {
+			continue
+		}
+		out = append(out, dag.DeepCopy())
+	}
+	return applyPaging(out, opts), nil
+}
+
+// DeleteDAG implements store.Store.
+func (s *Store) DeleteDAG(ctx context.Context, namespace, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.dags, dagKey(namespace, name))
+	prefix := dagKey(namespace, name) + "/"
+	for key := range s.taskStatuses {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.taskStatuses, key)
+		}
+	}
+	return nil
+}
+
+// SaveTaskStatus implements store.Store.
+func (s *Store) SaveTaskStatus(ctx context.Context, dagNamespace, dagName, runID, taskName string, status *workflowv1.TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := taskStatusKey(dagNamespace, dagName, runID)
+	if s.taskStatuses[key] == nil {
+		s.taskStatuses[key] = make(map[string]*workflowv1.TaskStatus)
+	}
+	copied := *status
+	s.taskStatuses[key][taskName] = &copied
+	return nil
+}
+
+// GetTaskStatus implements store.Store.
+func (s *Store) GetTaskStatus(ctx context.Context, dagNamespace, dagName, runID, taskName string) (*workflowv1.TaskStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses, ok := s.taskStatuses[taskStatusKey(dagNamespace, dagName, runID)]
+	if !ok {
+		return nil, fmt.Errorf("no task statuses for dag %s/%s run %q", dagNamespace, dagName, runID)
+	}
+	status, ok := statuses[taskName]
+	if !ok {
+		return nil, fmt.Errorf("task %s not found in dag %s/%s run %q", taskName, dagNamespace, dagName, runID)
+	}
+	copied := *status
+	return &copied, nil
+}
+
+// ListTaskStatuses implements store.Store.
+func (s *Store) ListTaskStatuses(ctx context.Context, dagNamespace, dagName, runID string) ([]workflowv1.TaskStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses, ok := s.taskStatuses[taskStatusKey(dagNamespace, dagName, runID)]
+	if !ok {
+		return nil, nil
+	}
+	out := make([]workflowv1.TaskStatus, 0, len(statuses))
+	for _, status := range statuses {
+		out = append(out, *status)
+	}
+	return out, nil
+}
+
+// SaveDAGRun implements store.Store.
+func (s *Store) SaveDAGRun(ctx context.Context, run *store.DAGRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *run
+	s.dagRuns[run.RunID] = &copied
+	return nil
+}
+
+// GetDAGRun implements store.Store.
+func (s *Store) GetDAGRun(ctx context.Context, runID string) (*store.DAGRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	run, ok := s.dagRuns[runID]
+	if !ok {
+		return nil, fmt.Errorf("dag run %s not found", runID)
+	}
+	copied := *run
+	return &copied, nil
+}
+
+// ListDAGRuns implements store.Store.
+func (s *Store) ListDAGRuns(ctx context.Context, dagNamespace, dagName string, opts store.ListOptions) ([]*store.DAGRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*store.DAGRun
+	for _, run := range s.dagRuns {
+		if run.DAGNamespace != dagNamespace || run.DAGName != dagName {
+			continue
+		}
+		if opts.State != "" && run.State != opts.State {
+			continue
+		}
+		copied := *run
+		out = append(out, &copied)
+	}
+	return applyRunPaging(out, opts), nil
+}
+
+// Ping implements store.Store. The in-memory store is always reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close implements store.Store. There are no resources to release.
+func (s *Store) Close() error {
+	return nil
+}
+
+func applyPaging(dags []*workflowv1.Dag, opts store.ListOptions) []*workflowv1.Dag {
+	if opts.Offset > 0 && opts.Offset < len(dags) {
+		dags = dags[opts.Offset:]
+	} else if opts.Offset >= len(dags) {
+		return nil
+	}
+	if opts.Limit > 0 && opts.Limit < len(dags) {
+		dags = dags[:opts.Limit]
+	}
+	return dags
+}
+
+func applyRunPaging(runs []*store.DAGRun, opts store.ListOptions) []*store.DAGRun {
+	if opts.Offset > 0 && opts.Offset < len(runs) {
+		runs = runs[opts.Offset:]
+	} else if opts.Offset >= len(runs) {
+		return nil
+	}
+	if opts.Limit > 0 && opts.Limit < len(runs) {
+		runs = runs[:opts.Limit]
+	}
+	return runs
+}
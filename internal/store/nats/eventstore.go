@@ -0,0 +1,214 @@
+// Package nats provides a store.EventStore implementation backed by NATS
+// JetStream, giving at-least-once delivery and per-DAG ordering by using the
+// DAG's namespace/name as the JetStream subject prefix.
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/kination/nautikus/internal/store"
+)
+
+const (
+	// streamName is the JetStream stream all workflow events are published to.
+	streamName = "NAUTIKUS_EVENTS"
+	// subjectPrefix namespaces events so Subscribe filters can use wildcards,
+	// e.g. "nautikus.events.<namespace>.<dag>.<type>".
+	subjectPrefix = "nautikus.events"
+)
+
+// EventStore implements store.EventStore on top of NATS JetStream.
+type EventStore struct {
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// New connects to NATS at url and ensures the backing stream exists.
+func New(ctx context.Context, url string) (*EventStore, error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      streamName,
+		Subjects:  []string{subjectPrefix + ".>"},
+		Retention: jetstream.LimitsPolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create event stream: %w", err)
+	}
+
+	return &EventStore{nc: nc, js: js}, nil
+}
+
+// Publish implements store.EventStore. At-least-once delivery is JetStream's
+// default: the message is acked only once durably stored.
+func (s *EventStore) Publish(ctx context.Context, event *store.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	subject := eventSubject(event)
+	if _, err := s.js.Publish(ctx, subject, data); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// Subscribe implements store.EventStore, returning events as they arrive on
+// an unnamed (ephemeral) consumer with explicit acks, scoped by filter.
+// Per-DAG ordering is kept
+// because all events for a DAG share the same subject prefix and JetStream
+// consumers deliver in publish order per subject.
+func (s *EventStore) Subscribe(ctx context.Context, filter store.EventFilter) (<-chan *store.Event, error) {
+	cons, err := s.js.CreateOrUpdateConsumer(ctx, streamName, jetstream.ConsumerConfig{
+		FilterSubject: filterSubject(filter),
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		DeliverPolicy: deliverPolicy(filter.Since),
+		OptStartTime:  filter.Since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	out := make(chan *store.Event, 64)
+	consumeCtx, err := cons.Consume(func(msg jetstream.Msg) {
+		event := &store.Event{}
+		if err := json.Unmarshal(msg.Data(), event); err != nil {
+			msg.Nak()
+			return
+		}
+		if !matches(event, filter) {
+			msg.Ack()
+			return
+		}
+		select {
+		case out <- event:
+			msg.Ack()
+		case <-ctx.Done():
+			msg.Nak()
+		}
+	})
+	if err != nil {
+		close(out)
+		return nil, fmt.Errorf("failed to start consuming: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		consumeCtx.Stop()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// GetEvents implements store.EventStore by replaying from filter.Since
+// (or the stream start) and collecting matches from a single fetch of at
+// most batchSize(opts) messages; later messages are not read.
+func (s *EventStore) GetEvents(ctx context.Context, filter store.EventFilter, opts store.ListOptions) ([]*store.Event, error) {
+	cons, err := s.js.OrderedConsumer(ctx, streamName, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{filterSubject(filter)},
+		DeliverPolicy:  deliverPolicy(filter.Since),
+		OptStartTime:   filter.Since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ordered consumer: %w", err)
+	}
+
+	var out []*store.Event
+	batch, err := cons.Fetch(batchSize(opts), jetstream.FetchMaxWait(2*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+	for msg := range batch.Messages() {
+		event := &store.Event{}
+		if err := json.Unmarshal(msg.Data(), event); err != nil {
+			continue
+		}
+		if matches(event, filter) {
+			out = append(out, event)
+		}
+	}
+	return applyOffset(out, opts), nil
+}
+
+// Close shuts down the underlying NATS connection.
+func (s *EventStore) Close() {
+	s.nc.Close()
+}
+
+func eventSubject(event *store.Event) string {
+	return fmt.Sprintf("%s.%s.%s.%s", subjectPrefix, event.DAGNamespace, event.DAGName, event.Type)
+}
+
+func filterSubject(filter store.EventFilter) string {
+	namespace := filter.DAGNamespace
+	if namespace == "" {
+		namespace = "*"
+	}
+	name := filter.DAGName
+	if name == "" {
+		name = "*"
+	}
+	return fmt.Sprintf("%s.%s.%s.>", subjectPrefix, namespace, name)
+}
+
+func deliverPolicy(since *time.Time) jetstream.DeliverPolicy {
+	if since != nil {
+		return jetstream.DeliverByStartTimePolicy
+	}
+	return jetstream.DeliverAllPolicy
+}
+
+func matches(event *store.Event, filter store.EventFilter) bool {
+	if len(filter.Types) > 0 {
+		found := false
+		for _, t := range filter.Types {
+			if t == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Until != nil && event.Timestamp.After(*filter.Until) {
+		return false
+	}
+	return true
+}
+
+func batchSize(opts store.ListOptions) int {
+	if opts.Limit > 0 {
+		return opts.Limit
+	}
+	return 256
+}
+
+func applyOffset(events []*store.Event, opts store.ListOptions) []*store.Event {
+	if opts.Offset <= 0 || opts.Offset >= len(events) {
+		if opts.Offset >= len(events) {
+			return nil
+		}
+		return events
+	}
+	return events[opts.Offset:]
+}
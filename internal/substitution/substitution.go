@@ -0,0 +1,193 @@
+// Package substitution resolves $(tasks.<name>.status) and
+// $(tasks.<name>.result.<key>) references in TaskSpec fields against a run's
+// current TaskStatuses, Tekton variable-substitution style.
+package substitution
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	workflowv1 "github.com/kination/nautikus/api/v1beta1"
+)
+
+var refPattern = regexp.MustCompile(`\$\(([^)]+)\)`)
+
+// Options configures how references resolve for a particular task.
+type Options struct {
+	// Finally marks that the task being substituted is one of
+	// dag.Spec.Finally, which additionally allows the aggregate
+	// $(tasks.status) reference.
+	Finally bool
+	// MainFailed is the $(tasks.status) value for Finally tasks: true if
+	// any non-Finally task failed.
+	MainFailed bool
+}
+
+// SubstituteTask returns a copy of task with $(...) references in Command,
+// Script and Env resolved against statuses. ok is false when any reference
+// couldn't be resolved yet (its task has no status at all, or a result key
+// it names hasn't been captured), meaning the caller should defer scheduling
+// this task rather than run it with a literal "$(...)" in its command. A
+// $(tasks.<name>.status) reference to a task that has a status but isn't
+// terminal resolves to "None" rather than deferring.
+func SubstituteTask(task workflowv1.TaskSpec, statuses map[string]workflowv1.TaskStatus, opts Options) (workflowv1.TaskSpec, bool) {
+	var allUnresolved []string
+
+	command, unresolved := substituteString(task.Command, statuses, opts)
+	allUnresolved = append(allUnresolved, unresolved...)
+	task.Command = command
+
+	script, unresolved := substituteString(task.Script, statuses, opts)
+	allUnresolved = append(allUnresolved, unresolved...)
+	task.Script = script
+
+	if len(task.Env) > 0 {
+		env := make(map[string]string, len(task.Env))
+		for k, v := range task.Env {
+			resolved, u := substituteString(v, statuses, opts)
+			allUnresolved = append(allUnresolved, u...)
+			env[k] = resolved
+		}
+		task.Env = env
+	}
+
+	return task, len(allUnresolved) == 0
+}
+
+// substituteString replaces every $(...) reference in s. unresolved
+// collects refs that couldn't be resolved; ValidateReferences is what
+// catches refs that name a task that doesn't exist at all, so an unresolved
+// ref here just means "not ready yet".
+func substituteString(s string, statuses map[string]workflowv1.TaskStatus, opts Options) (string, []string) {
+	if s == "" {
+		return s, nil
+	}
+
+	var unresolved []string
+	result := refPattern.ReplaceAllStringFunc(s, func(match string) string {
+		ref := refPattern.FindStringSubmatch(match)[1]
+		val, ok := resolve(ref, statuses, opts)
+		if !ok {
+			unresolved = append(unresolved, ref)
+			return match
+		}
+		return val
+	})
+	return result, unresolved
+}
+
+// resolve looks up a single $(...) reference's value against statuses.
+func resolve(ref string, statuses map[string]workflowv1.TaskStatus, opts Options) (string, bool) {
+	if ref == "tasks.status" {
+		if !opts.Finally {
+			return "", false
+		}
+		if opts.MainFailed {
+			return "Failed", true
+		}
+		return "Succeeded", true
+	}
+
+	if !strings.HasPrefix(ref, "tasks.") {
+		return "", false
+	}
+	rest := strings.TrimPrefix(ref, "tasks.")
+
+	name, field, found := strings.Cut(rest, ".")
+	if !found {
+		return "", false
+	}
+
+	status, exists := statuses[name]
+	if !exists {
+		return "", false
+	}
+
+	if field == "status" {
+		switch status.State {
+		case workflowv1.StateCompleted, workflowv1.StateSkipped:
+			return "Succeeded", true
+		case workflowv1.StateFailed:
+			return "Failed", true
+		default:
+			return "None", true
+		}
+	}
+
+	const resultPrefix = "result."
+	if strings.HasPrefix(field, resultPrefix) {
+		key := strings.TrimPrefix(field, resultPrefix)
+		val, ok := status.Results[key]
+		if !ok {
+			return "", false
+		}
+		return val, true
+	}
+
+	return "", false
+}
+
+// ValidateReferences checks every $(tasks...) reference in dag against the
+// task names actually declared in dag.Spec.Tasks/Finally, returning an
+// error naming the first invalid one. DagReconciler.Reconcile calls it and
+// marks a Dag that fails it Failed; no admission webhook calls it, so an
+// invalid Dag is still accepted and persisted.
+func ValidateReferences(dag *workflowv1.Dag) error {
+	known := make(map[string]bool, len(dag.Spec.Tasks)+len(dag.Spec.Finally))
+	for _, t := range dag.Spec.Tasks {
+		known[t.Name] = true
+	}
+	for _, t := range dag.Spec.Finally {
+		known[t.Name] = true
+	}
+
+	for _, t := range dag.Spec.Tasks {
+		if err := validateTask(t, known, false); err != nil {
+			return err
+		}
+	}
+	for _, t := range dag.Spec.Finally {
+		if err := validateTask(t, known, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTask(task workflowv1.TaskSpec, known map[string]bool, finally bool) error {
+	strs := []string{task.Command, task.Script}
+	for _, v := range task.Env {
+		strs = append(strs, v)
+	}
+	for _, s := range strs {
+		if err := validateString(s, known, finally); err != nil {
+			return fmt.Errorf("task %s: %w", task.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateString(s string, known map[string]bool, finally bool) error {
+	for _, match := range refPattern.FindAllStringSubmatch(s, -1) {
+		ref := match[1]
+		if ref == "tasks.status" {
+			if !finally {
+				return fmt.Errorf("$(tasks.status) is only valid inside finally tasks")
+			}
+			continue
+		}
+		if !strings.HasPrefix(ref, "tasks.") {
+			return fmt.Errorf("unsupported reference $(%s)", ref)
+		}
+		rest := strings.TrimPrefix(ref, "tasks.")
+		name, _, found := strings.Cut(rest, ".")
+		if !found {
+			return fmt.Errorf("malformed reference $(%s)", ref)
+		}
+		if !known[name] {
+			return fmt.Errorf("reference $(%s) names unknown task %q", ref, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// Package conversion wires up the /convert endpoint that lets existing
+// workflow.nautikus.io/v1alpha1 Dag manifests keep working now that
+// api/v1beta1 is the storage version. The actual field mapping lives on the
+// api/v1alpha1 types themselves (ConvertTo/ConvertFrom, required by
+// controller-runtime's conversion.Convertible interface); this package only
+// builds the generic webhook handler controller-runtime serves requests
+// through, so it has no per-type knowledge and does not import the API
+// packages itself.
+package conversion
+
+import (
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// NewHandler returns the /convert endpoint's http.Handler for scheme, which
+// must have every convertible API version (api/v1alpha1, api/v1beta1)
+// registered.
+func NewHandler(scheme *runtime.Scheme) http.Handler {
+	return conversion.NewWebhookHandler(scheme)
+}
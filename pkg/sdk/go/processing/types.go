@@ -7,8 +7,31 @@ const (
 	TaskTypeSimple TaskType = iota // Normal task
 	TaskTypeBranch                 // Conditional branch selector
 	TaskTypeJoin                   // Waits for any upstream branch
+	TaskTypeCustom                 // Delegated to an ExternalResourceConnector, Tekton Run style
+	// TaskTypeSysBatch generates a workflowv1.TaskTypeSysBatch TaskSpec,
+	// fanned out by the scheduler to run once on every eligible node.
+	TaskTypeSysBatch
 )
 
+// CustomTaskRef carries the connector and inline spec for a TaskTypeCustom
+// task, mirrored into workflowv1.TaskSpec.CustomRef when the manifest is
+// generated.
+type CustomTaskRef struct {
+	APIVersion string
+	Kind       string
+	Spec       []byte // raw JSON, passed through as json.RawMessage
+}
+
+// BranchRule maps a small boolean expression over an upstream task's
+// state/outputs to the branch that should run when it matches. Mirrored
+// into workflowv1.TaskSpec.BranchRules when the manifest is generated, so
+// the Runner decides against the run's task states/outputs instead of
+// BranchFn deciding inside the branch task's own Pod.
+type BranchRule struct {
+	Expression   string
+	TargetBranch string
+}
+
 // TaskDef is the internal representation of a task for processing
 type TaskDef struct {
 	Name            string
@@ -19,4 +42,7 @@ type TaskDef struct {
 	BranchTargets   []string // For branch tasks: possible branch names
 	BranchCondition string   // For conditional tasks: which branch this belongs to
 	ConditionSource string   // For conditional tasks: which branch task determines execution
+	CustomRef       *CustomTaskRef // For TaskTypeCustom tasks: the connector and payload to dispatch to
+	BranchRules     []BranchRule   // For branch tasks: runtime rules: set instead of BranchFn for data-dependent decisions
+	NodeSelector    map[string]string // For TaskTypeSysBatch tasks: node labels the scheduler fans out onto
 }